@@ -0,0 +1,69 @@
+package i18ngo
+
+import (
+	"io"
+	"io/fs"
+	"strconv"
+	"text/template"
+
+	"github.com/danicc097/i18ngo/templates"
+	"github.com/kenshaw/snaker"
+)
+
+var (
+	// BackendTextTemplate renders Go code by executing templates/template.go.tpl with text/template.
+	BackendTextTemplate templates.Backend = textTemplateBackend{fsys: templateFS}
+	// BackendTempl renders the same Go code as BackendTextTemplate with compiled templ components.
+	BackendTempl templates.Backend = templates.TemplBackend{}
+)
+
+// backend returns the backend set by opts, BackendTextTemplate by default.
+func backend(opts []GenerateOption) templates.Backend {
+	optsMap := &generateOptions{}
+	for _, o := range opts {
+		o(optsMap)
+	}
+	if optsMap.Backend == nil {
+		return BackendTextTemplate
+	}
+	return optsMap.Backend
+}
+
+// textTemplateBackend executes templates/template.go.tpl in fsys.
+type textTemplateBackend struct {
+	fsys fs.FS
+}
+
+func (b textTemplateBackend) Translations(w io.Writer, data *templates.TemplateData) error {
+	return b.execute(w, "template.go.tpl", data)
+}
+
+func (b textTemplateBackend) Locale(w io.Writer, data templates.LocaleFileData) error {
+	return b.execute(w, "locale", data)
+}
+
+func (b textTemplateBackend) ExamplesTest(w io.Writer, data *templates.TemplateData) error {
+	return b.execute(w, "examplesTest", data)
+}
+
+func (b textTemplateBackend) execute(w io.Writer, name string, data any) error {
+	funcMap := template.FuncMap{
+		"camelCase": func(s string) string {
+			return snaker.ForceLowerCamelIdentifier(s)
+		},
+		"pascalCase": func(s string) string {
+			return snaker.ForceCamelIdentifier(s)
+		},
+		"quote":             strconv.Quote,
+		"funcsCode":         templates.FuncsCode,
+		"funcsImports":      templates.FuncsImports,
+		"localeFormatsCode": templates.LocaleFormatsCode,
+	}
+
+	tmpl, err := template.New("template.go.tpl").Funcs(funcMap).ParseFS(b.fsys, "templates/template.go.tpl")
+	if err != nil {
+		return err
+	}
+
+	return tmpl.ExecuteTemplate(w, name, data)
+}
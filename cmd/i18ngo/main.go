@@ -1,26 +1,197 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/danicc097/i18ngo"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "docs" {
+		docs(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		export(os.Args[2:])
+		return
+	}
+
+	compiled := flag.Bool("compiled", false, "compile simple templates to plain Go code")
+	argsStructs := flag.Bool("args-structs", false, "generate an arguments struct per message instead of positional parameters")
+	lazy := flag.Bool("lazy", false, "parse templates on first use instead of at initialization")
+	dev := flag.Bool("dev", false, "generate NewDevTranslators, reading translation files at runtime")
+	templComponents := flag.Bool("templ-components", false, "generate a templ.Component per message")
+	memoized := flag.Bool("memoized", false, "generate memoized translators caching rendered messages")
+	testTranslators := flag.Bool("test-translators", false, "generate key-echo and recording translators for tests")
+	render := flag.Bool("render", false, "generate Render, rendering messages by id with map arguments")
+	langMatcher := flag.Bool("lang-matcher", false, "generate Matcher, MatchLang and ParseLang")
+	langMiddleware := flag.Bool("lang-middleware", false, "generate a net/http middleware storing the request language in its context")
+	split := flag.Bool("split-locales", false, "write shared declarations and a file per locale to -out instead of stdout")
+	buildTags := flag.Bool("locale-build-tags", false, "constrain each non-base locale file with an i18n_<locale> build tag")
+	tests := flag.String("tests", "", "write a table test rendering message examples to the given file")
+	unverified := flag.Bool("unverified-examples", false, "allow examples that can't be evaluated at generation time, leaving them to -tests")
+	out := flag.String("out", ".", "output directory for -split-locales")
+	target := flag.String("target", string(i18ngo.TargetGo), "language to generate: go or ts")
+	baseLang := flag.String("base-lang", "", "fallback language when none is set (default: first language)")
+	backend := flag.String("backend", "template", "Go code backend: template or templ")
+	flag.Parse()
+
 	// create fs.FS from cli arg of directory --> first arg.
-	fs := os.DirFS(os.Args[1])
-	pkgName := os.Args[2]
+	fs := os.DirFS(flag.Arg(0))
+	pkgName := flag.Arg(1)
 
-	data, err := i18ngo.GetTranslationData(fs, ".", pkgName)
+	var opts []i18ngo.GenerateOption
+	if *compiled {
+		opts = append(opts, i18ngo.WithCompiledTemplates())
+	}
+	if *baseLang != "" {
+		opts = append(opts, i18ngo.WithBaseLang(*baseLang))
+	}
+	if *argsStructs {
+		opts = append(opts, i18ngo.WithArgsStructs())
+	}
+	if *lazy {
+		opts = append(opts, i18ngo.WithLazyTemplates())
+	}
+	if *dev {
+		opts = append(opts, i18ngo.WithDevTranslators())
+	}
+	if *templComponents {
+		opts = append(opts, i18ngo.WithTemplComponents())
+	}
+	if *memoized {
+		opts = append(opts, i18ngo.WithMemoizedTranslators())
+	}
+	if *testTranslators {
+		opts = append(opts, i18ngo.WithTestTranslators())
+	}
+	if *render {
+		opts = append(opts, i18ngo.WithRender())
+	}
+	if *langMatcher {
+		opts = append(opts, i18ngo.WithLangMatcher())
+	}
+	if *langMiddleware {
+		opts = append(opts, i18ngo.WithLangMiddleware())
+	}
+	if *target != string(i18ngo.TargetGo) {
+		opts = append(opts, i18ngo.WithTarget(i18ngo.Target(*target)))
+	}
+	if *buildTags {
+		opts = append(opts, i18ngo.WithLocaleBuildTags())
+	}
+	if *unverified {
+		opts = append(opts, i18ngo.WithUnverifiedExamples())
+	}
+
+	var genOpts []i18ngo.GenerateOption
+	switch *backend {
+	case "template":
+	case "templ":
+		genOpts = append(genOpts, i18ngo.WithBackend(i18ngo.BackendTempl))
+	default:
+		panic(fmt.Sprintf("unknown backend %q", *backend))
+	}
+
+	data, err := i18ngo.GetTranslationData(fs, ".", pkgName, opts...)
 	if err != nil {
 		panic(err)
 	}
+	for _, ex := range data.UnverifiedExamples() {
+		fmt.Fprintf(os.Stderr, "unverified %s\n", ex)
+	}
+
+	if *split {
+		// i18n_test.go is included when examples exist.
+		files, err := i18ngo.GenerateFiles(data, genOpts...)
+		if err != nil {
+			panic(err)
+		}
+		for name, src := range files {
+			if err := os.WriteFile(filepath.Join(*out, name), src, 0o644); err != nil {
+				panic(err)
+			}
+		}
+		return
+	}
 
-	src, err := i18ngo.Generate(data)
+	if *tests != "" {
+		src, err := i18ngo.GenerateTests(data, genOpts...)
+		if err != nil {
+			panic(err)
+		}
+		if src != nil {
+			if err := os.WriteFile(*tests, src, 0o644); err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	src, err := i18ngo.Generate(data, genOpts...)
 	if err != nil {
 		panic(err)
 	}
 
 	fmt.Fprint(os.Stdout, string(src))
 }
+
+// docs writes catalog documentation to stdout: i18ngo docs [flags] <dir> [pkg].
+func docs(args []string) {
+	fset := flag.NewFlagSet("docs", flag.ExitOnError)
+	format := fset.String("format", string(i18ngo.DocsMarkdown), "output format: markdown or html")
+	baseLang := fset.String("base-lang", "", "language other locales are compared with (default: first language)")
+	fset.Parse(args)
+
+	pkgName := fset.Arg(1)
+	if pkgName == "" {
+		pkgName = "i18n"
+	}
+
+	var opts []i18ngo.GenerateOption
+	if *baseLang != "" {
+		opts = append(opts, i18ngo.WithBaseLang(*baseLang))
+	}
+
+	data, err := i18ngo.GetTranslationData(os.DirFS(fset.Arg(0)), ".", pkgName, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	out, err := i18ngo.GenerateDocs(data, i18ngo.DocsFormat(*format))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Fprint(os.Stdout, string(out))
+}
+
+// export writes a JSON bundle per locale and a manifest to -out: i18ngo export [flags] <dir>.
+// Messages that can't be converted are reported to stderr.
+func export(args []string) {
+	fset := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fset.String("format", string(i18ngo.ExportI18next), "bundle format: i18next or formatjs")
+	out := fset.String("out", ".", "output directory")
+	fset.Parse(args)
+
+	data, err := i18ngo.GetTranslationData(os.DirFS(fset.Arg(0)), ".", "i18n")
+	if err != nil {
+		panic(err)
+	}
+
+	res, err := i18ngo.Export(data, i18ngo.ExportFormat(*format))
+	if err != nil {
+		panic(err)
+	}
+
+	for name, b := range res.Files {
+		if err := os.WriteFile(filepath.Join(*out, name), b, 0o644); err != nil {
+			panic(err)
+		}
+	}
+	for _, m := range res.Unconverted {
+		fmt.Fprintf(os.Stderr, "skipped %v\n", m.Error())
+	}
+}
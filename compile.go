@@ -0,0 +1,185 @@
+package i18ngo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template/parse"
+
+	"github.com/danicc097/i18ngo/templates"
+)
+
+// templateCompiler translates the simple template subset (text, fields, if/else and printf)
+// into Go statements writing to a strings.Builder named b.
+type templateCompiler struct {
+	vars map[string]templates.VarData
+	ref  func(templates.VarData) string
+	buf  strings.Builder
+}
+
+// compileTemplate returns Go code equivalent to executing tpl with html/template,
+// or nil if tpl uses constructs that can only be rendered through template execution.
+func compileTemplate(tpl string, vars []templates.VarData, ref func(templates.VarData) string) *templates.CompiledTemplate {
+	tree := parse.New("")
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse(tpl, "", "", map[string]*parse.Tree{}); err != nil {
+		return nil
+	}
+
+	c := &templateCompiler{vars: make(map[string]templates.VarData), ref: ref}
+	for _, v := range vars {
+		c.vars[v.Name] = v
+	}
+	if !c.list(tree.Root) {
+		return nil
+	}
+
+	return &templates.CompiledTemplate{Code: strings.TrimSuffix(c.buf.String(), "\n")}
+}
+
+func (c *templateCompiler) list(list *parse.ListNode) bool {
+	if list == nil {
+		return true
+	}
+	for _, node := range list.Nodes {
+		if !c.node(node) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *templateCompiler) node(node parse.Node) bool {
+	switch n := node.(type) {
+	case *parse.TextNode:
+		// html/template changes the escaping context inside tags and attributes.
+		if strings.ContainsRune(string(n.Text), '<') {
+			return false
+		}
+		fmt.Fprintf(&c.buf, "b.WriteString(%s)\n", strconv.Quote(string(n.Text)))
+	case *parse.ActionNode:
+		if len(n.Pipe.Decl) > 0 || len(n.Pipe.Cmds) != 1 {
+			return false
+		}
+		expr, ok := c.command(n.Pipe.Cmds[0])
+		if !ok {
+			return false
+		}
+		fmt.Fprintf(&c.buf, "b.WriteString(%s)\n", expr)
+	case *parse.IfNode:
+		cond, ok := c.condition(n.Pipe)
+		if !ok {
+			return false
+		}
+		fmt.Fprintf(&c.buf, "if %s {\n", cond)
+		if !c.list(n.List) {
+			return false
+		}
+		if n.ElseList != nil {
+			c.buf.WriteString("} else {\n")
+			if !c.list(n.ElseList) {
+				return false
+			}
+		}
+		c.buf.WriteString("}\n")
+	default:
+		return false
+	}
+
+	return true
+}
+
+// command returns an escaped string expression for a field or printf call.
+func (c *templateCompiler) command(cmd *parse.CommandNode) (string, bool) {
+	switch arg := cmd.Args[0].(type) {
+	case *parse.FieldNode:
+		v, ok := c.field(arg)
+		if !ok || len(cmd.Args) != 1 {
+			return "", false
+		}
+		return c.format(v)
+	case *parse.IdentifierNode:
+		if arg.Ident != "printf" || len(cmd.Args) < 2 {
+			return "", false
+		}
+		args := make([]string, 0, len(cmd.Args)-1)
+		for _, a := range cmd.Args[1:] {
+			switch a := a.(type) {
+			case *parse.FieldNode:
+				v, ok := c.field(a)
+				if !ok {
+					return "", false
+				}
+				args = append(args, c.ref(v))
+			case *parse.StringNode, *parse.NumberNode, *parse.BoolNode:
+				args = append(args, a.String())
+			default:
+				return "", false
+			}
+		}
+		return fmt.Sprintf("escapeHTML(fmt.Sprintf(%s))", strings.Join(args, ", ")), true
+	}
+
+	return "", false
+}
+
+// condition returns a boolean expression matching template truthiness for a single field.
+func (c *templateCompiler) condition(pipe *parse.PipeNode) (string, bool) {
+	if len(pipe.Decl) > 0 || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return "", false
+	}
+	field, ok := pipe.Cmds[0].Args[0].(*parse.FieldNode)
+	if !ok {
+		return "", false
+	}
+	v, ok := c.field(field)
+	if !ok {
+		return "", false
+	}
+
+	switch v.Type {
+	case "bool":
+		return c.ref(v), true
+	case "string":
+		return c.ref(v) + ` != ""`, true
+	case "int", "int8", "int16", "int32", "int64", "rune",
+		"uint", "uint8", "uint16", "uint32", "uint64", "byte",
+		"float32", "float64":
+		return c.ref(v) + " != 0", true
+	}
+
+	return "", false
+}
+
+func (c *templateCompiler) field(f *parse.FieldNode) (templates.VarData, bool) {
+	if len(f.Ident) != 1 {
+		return templates.VarData{}, false
+	}
+	v, ok := c.vars[f.Ident[0]]
+
+	return v, ok
+}
+
+// format returns a string expression for v, escaped the same way html/template would.
+func (c *templateCompiler) format(v templates.VarData) (string, bool) {
+	ref := c.ref(v)
+	switch v.Type {
+	case "string":
+		return fmt.Sprintf("escapeHTML(%s)", ref), true
+	case "bool":
+		return fmt.Sprintf("strconv.FormatBool(%s)", ref), true
+	case "int":
+		return fmt.Sprintf("strconv.Itoa(%s)", ref), true
+	case "int8", "int16", "int32", "int64", "rune":
+		return fmt.Sprintf("strconv.FormatInt(int64(%s), 10)", ref), true
+	case "uint", "uint8", "uint16", "uint32", "uint64", "byte":
+		return fmt.Sprintf("strconv.FormatUint(uint64(%s), 10)", ref), true
+	case "float32":
+		return fmt.Sprintf("escapeHTML(strconv.FormatFloat(float64(%s), 'g', -1, 32))", ref), true
+	case "float64":
+		return fmt.Sprintf("escapeHTML(strconv.FormatFloat(%s, 'g', -1, 64))", ref), true
+	}
+
+	return "", false
+}
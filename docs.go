@@ -0,0 +1,174 @@
+package i18ngo
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"text/template"
+
+	"github.com/danicc097/i18ngo/templates"
+)
+
+//go:embed templates/docs.md.tpl templates/docs.html.tpl
+var docsFS embed.FS
+
+// DocsFormat is an output format of GenerateDocs.
+type DocsFormat string
+
+const (
+	DocsMarkdown DocsFormat = "markdown"
+	DocsHTML     DocsFormat = "html"
+)
+
+type docsData struct {
+	*templates.TemplateData
+	Messages []docsMessage
+	Locales  []docsLocale
+}
+
+// docsMessage is a message of the base language with its translations in every locale.
+type docsMessage struct {
+	templates.MessageData
+	Translations []docsTranslation
+}
+
+type docsTranslation struct {
+	Lang            string
+	Template        string
+	CustomTemplates []templates.CustomTemplate
+}
+
+// docsLocale summarizes the completeness of a locale.
+type docsLocale struct {
+	Lang       string
+	Total      int
+	Translated int
+	Missing    int
+	// SameAsBase counts messages whose templates are identical to the base language,
+	// which may not have been translated yet.
+	SameAsBase int
+}
+
+func (l docsLocale) Percent() int {
+	if l.Total == 0 {
+		return 100
+	}
+	return l.Translated * 100 / l.Total
+}
+
+// GenerateDocs renders the catalog as documentation for translators and product managers:
+// messages with their method signature, variables and templates per locale, and the completeness of each locale.
+func GenerateDocs(data *templates.TemplateData, format DocsFormat) ([]byte, error) {
+	if data == nil {
+		return nil, fmt.Errorf("data must be non-nil")
+	}
+
+	docs := newDocsData(data)
+
+	var buf bytes.Buffer
+	switch format {
+	case DocsMarkdown:
+		tmpl := template.Must(template.New("docs.md.tpl").Funcs(template.FuncMap{
+			"code": markdownCode,
+		}).ParseFS(docsFS, "templates/docs.md.tpl"))
+		if err := tmpl.Execute(&buf, docs); err != nil {
+			return nil, fmt.Errorf("error executing template: %w", err)
+		}
+	case DocsHTML:
+		tmpl := htmltemplate.Must(htmltemplate.New("docs.html.tpl").ParseFS(docsFS, "templates/docs.html.tpl"))
+		if err := tmpl.Execute(&buf, docs); err != nil {
+			return nil, fmt.Errorf("error executing template: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown docs format %q", format)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func newDocsData(data *templates.TemplateData) docsData {
+	var base templates.TranslationData
+	for _, tr := range data.Translations {
+		if tr.Lang == data.BaseLang.Lang {
+			base = tr
+		}
+	}
+
+	docs := docsData{TemplateData: data}
+	for i, msg := range base.Messages {
+		dm := docsMessage{MessageData: msg}
+		for _, tr := range data.Translations {
+			// all translations have the same messages
+			m := tr.Messages[i]
+			dm.Translations = append(dm.Translations, docsTranslation{
+				Lang:            tr.Lang,
+				Template:        m.Template,
+				CustomTemplates: m.CustomTemplates,
+			})
+		}
+		docs.Messages = append(docs.Messages, dm)
+	}
+
+	for _, tr := range data.Translations {
+		locale := docsLocale{Lang: tr.Lang, Total: len(tr.Messages)}
+		for i, msg := range tr.Messages {
+			if !isTranslated(msg) {
+				locale.Missing++
+				continue
+			}
+			locale.Translated++
+			if tr.Lang != base.Lang && sameTemplates(msg, base.Messages[i]) {
+				locale.SameAsBase++
+			}
+		}
+		docs.Locales = append(docs.Locales, locale)
+	}
+
+	return docs
+}
+
+// isTranslated reports whether the message has no empty templates.
+func isTranslated(msg templates.MessageData) bool {
+	if strings.TrimSpace(msg.Template) == "" {
+		return false
+	}
+	for _, ct := range msg.CustomTemplates {
+		if strings.TrimSpace(ct.Template) == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sameTemplates(a, b templates.MessageData) bool {
+	if a.Template != b.Template || len(a.CustomTemplates) != len(b.CustomTemplates) {
+		return false
+	}
+	for i := range a.CustomTemplates {
+		if a.CustomTemplates[i].Template != b.CustomTemplates[i].Template {
+			return false
+		}
+	}
+
+	return true
+}
+
+// markdownCode formats s as inline code, or as missing if empty.
+func markdownCode(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "_missing_"
+	}
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	fence := "`"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+
+	return fence + s + fence
+}
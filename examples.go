@@ -0,0 +1,261 @@
+package i18ngo
+
+import (
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"html/template"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/danicc097/i18ngo/internal/load"
+	"github.com/danicc097/i18ngo/templates"
+	"github.com/danicc097/i18ngo/validator"
+	"github.com/kenshaw/snaker"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// exampleChecker verifies message examples and converts them to Go calls for generated tests.
+type exampleChecker struct {
+	checker *validator.TypeChecker
+	msg     templates.Message
+	vars    []templates.VarData
+	types   map[string]types.Type // by variable name
+	// argsType is the arguments struct of the message method, if any.
+	argsType string
+	// tag is the language of the message, whose rules template functions follow.
+	tag language.Tag
+	// allowUnverified leaves examples that can't be evaluated at generation time to generated tests
+	// instead of failing.
+	allowUnverified bool
+}
+
+// examples verifies each example renders its wanted text through the templates and expressions
+// the generated translators use, when they can be evaluated at generation time.
+func (c *exampleChecker) examples() ([]templates.ExampleData, error) {
+	examples := make([]templates.ExampleData, 0, len(c.msg.Examples))
+	for i, ex := range c.msg.Examples {
+		data, err := c.example(ex)
+		if err != nil {
+			return nil, fmt.Errorf("example %d: %w", i, err)
+		}
+		examples = append(examples, data)
+	}
+
+	return examples, nil
+}
+
+func (c *exampleChecker) example(ex templates.Example) (templates.ExampleData, error) {
+	var unknown []string
+	for name := range ex.Args {
+		if _, ok := c.types[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return templates.ExampleData{}, fmt.Errorf("unknown variables %s", strings.Join(unknown, ", "))
+	}
+
+	args := make([]string, 0, len(c.vars))
+	exprVars := make(map[string]types.Type, len(c.vars))
+	exprValues := make(map[string]constant.Value, len(c.vars))
+	data := make(map[string]any, len(c.vars))
+	var unverified string
+	for _, v := range c.vars {
+		value, ok := ex.Args[v.Name]
+		if !ok {
+			return templates.ExampleData{}, fmt.Errorf("missing variable %s", v.Name)
+		}
+		typ := c.types[v.Name]
+		lit, goValue, cst, err := exampleValue(v, typ, value)
+		if err != nil {
+			return templates.ExampleData{}, fmt.Errorf("variable %s: %w", v.Name, err)
+		}
+		if c.argsType != "" {
+			lit = v.Name + ": " + lit
+		}
+		args = append(args, lit)
+		data[v.Name] = goValue
+		// Named types render like their underlying type unless templates use their methods,
+		// which can't be called at generation time.
+		if _, known := knownType(typ); !known && types.NewMethodSet(typ).Len() > 0 && unverified == "" {
+			unverified = fmt.Sprintf("variable %s has type %s with methods", v.Name, v.Type)
+		}
+		if _, declared := c.msg.Variables[v.Name]; declared {
+			exprVars[v.Param] = typ
+			if cst != nil {
+				exprValues[v.Param] = cst
+			}
+		}
+	}
+
+	call := strings.Join(args, ", ")
+	if c.argsType != "" {
+		call = c.argsType + "{" + call + "}"
+	}
+	example := templates.ExampleData{Call: call, Want: ex.Want}
+	if unverified != "" {
+		return c.unverified(example, unverified)
+	}
+
+	tpl := c.msg.Template
+	for _, ct := range c.msg.CustomTemplates {
+		if ct.Plural != nil {
+			n := exprValues[snaker.ForceLowerCamelIdentifier(ct.Plural.Variable)]
+			if constant.Sign(n) < 0 {
+				n = constant.UnaryOp(token.SUB, n, 0)
+			}
+			// Example values fit in the integer type of the variable, so their magnitude fits in a uint64.
+			abs, _ := constant.Uint64Val(n)
+			rules := plural.Cardinal
+			if ct.Plural.Ordinal {
+				rules = plural.Ordinal
+			}
+			if load.PluralFormName(rules.MatchPlural(c.tag, load.PluralOperand(abs), 0, 0, 0, 0)) == ct.Plural.Form {
+				tpl = ct.Template
+				break
+			}
+			continue
+		}
+		matches, ok, err := c.checker.EvalExpression(ct.Expression, exprVars, exprValues)
+		if err != nil {
+			return templates.ExampleData{}, err
+		}
+		if !ok {
+			return c.unverified(example, fmt.Sprintf("custom template expression %q doesn't evaluate to a constant", ct.Expression))
+		}
+		if matches {
+			tpl = ct.Template
+			break
+		}
+	}
+
+	t, err := template.New("example").Funcs(templates.Funcs(c.tag)).Parse(tpl)
+	if err != nil {
+		return templates.ExampleData{}, fmt.Errorf("invalid template: %w", err)
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return templates.ExampleData{}, fmt.Errorf("error rendering template: %w", err)
+	}
+	if got := b.String(); got != ex.Want {
+		return templates.ExampleData{}, fmt.Errorf("got %q, want %q", got, ex.Want)
+	}
+
+	return example, nil
+}
+
+// unverified records why an example can't be evaluated at generation time,
+// failing unless unverified examples are allowed.
+func (c *exampleChecker) unverified(example templates.ExampleData, reason string) (templates.ExampleData, error) {
+	if !c.allowUnverified {
+		return templates.ExampleData{}, fmt.Errorf("can't be verified at generation time: %s", reason)
+	}
+	example.Unverified = reason
+
+	return example, nil
+}
+
+// exampleValue converts a YAML example value of variable v to a Go literal, a Go value to render
+// templates with and a constant for expressions. Variables not declared in translation files
+// accept any basic value.
+func exampleValue(v templates.VarData, typ types.Type, value any) (lit string, goValue any, cst constant.Value, err error) {
+	if _, ok := typ.Underlying().(*types.Interface); ok {
+		lit, err := defaultLiteral(value)
+		return lit, value, nil, err
+	}
+
+	cst, err = validator.ExampleValue(typ, value)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	basic := typ.Underlying().(*types.Basic)
+	switch basic.Kind() {
+	case types.Bool:
+		goValue = constant.BoolVal(cst)
+	case types.String:
+		goValue = constant.StringVal(cst)
+	case types.Float32:
+		f, _ := constant.Float32Val(cst)
+		goValue = f
+	case types.Float64:
+		goValue, _ = constant.Float64Val(cst)
+	default:
+		if i, ok := constant.Int64Val(cst); ok {
+			goValue = i
+		} else {
+			goValue, _ = constant.Uint64Val(cst)
+		}
+	}
+	if known, ok := knownType(typ); ok {
+		goValue = known(goValue)
+	}
+
+	lit, err = defaultLiteral(value)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if !types.Identical(typ, types.Default(types.Typ[basicUntyped(cst.Kind())])) {
+		lit = v.Type + "(" + lit + ")"
+	}
+
+	return lit, goValue, cst, nil
+}
+
+// knownTypes converts values of the underlying type of named types the generator depends on
+// to the named type, so that examples render through their methods, by qualified type name.
+var knownTypes = map[string]func(any) any{
+	// Content types are trusted by templates instead of escaped.
+	"html/template.CSS":      func(v any) any { return template.CSS(v.(string)) },
+	"html/template.HTML":     func(v any) any { return template.HTML(v.(string)) },
+	"html/template.HTMLAttr": func(v any) any { return template.HTMLAttr(v.(string)) },
+	"html/template.JS":       func(v any) any { return template.JS(v.(string)) },
+	"html/template.JSStr":    func(v any) any { return template.JSStr(v.(string)) },
+	"html/template.Srcset":   func(v any) any { return template.Srcset(v.(string)) },
+	"html/template.URL":      func(v any) any { return template.URL(v.(string)) },
+}
+
+// knownType returns the conversion to typ if it's one of knownTypes.
+func knownType(typ types.Type) (func(any) any, bool) {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil, false
+	}
+	known, ok := knownTypes[named.Obj().Pkg().Path()+"."+named.Obj().Name()]
+
+	return known, ok
+}
+
+func basicUntyped(kind constant.Kind) types.BasicKind {
+	switch kind {
+	case constant.Bool:
+		return types.UntypedBool
+	case constant.String:
+		return types.UntypedString
+	case constant.Int:
+		return types.UntypedInt
+	}
+	return types.UntypedFloat
+}
+
+// defaultLiteral returns the Go literal of a value decoded from YAML.
+func defaultLiteral(value any) (string, error) {
+	switch value := value.(type) {
+	case bool:
+		return strconv.FormatBool(value), nil
+	case string:
+		return strconv.Quote(value), nil
+	case int:
+		return strconv.Itoa(value), nil
+	case uint64:
+		return strconv.FormatUint(value, 10), nil
+	case float64:
+		return strconv.FormatFloat(value, 'g', -1, 64), nil
+	}
+	return "", fmt.Errorf("unsupported value %v", value)
+}
@@ -0,0 +1,462 @@
+package i18ngo
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template/parse"
+
+	"github.com/danicc097/i18ngo/internal/load"
+	"github.com/danicc097/i18ngo/templates"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// ExportFormat is a frontend i18n library Export writes JSON bundles for.
+type ExportFormat string
+
+const (
+	// ExportI18next writes i18next JSON v4 bundles, with plural suffixes for counts.
+	ExportI18next ExportFormat = "i18next"
+	// ExportFormatJS writes FormatJS bundles of ICU messages.
+	ExportFormatJS ExportFormat = "formatjs"
+)
+
+// ExportManifest is the name of the file mapping each language to its bundle.
+const ExportManifest = "manifest.json"
+
+// ExportResult holds the files written by Export.
+type ExportResult struct {
+	// Files are the bundles, named <lang>.<hash>.json after their content, and the manifest.
+	Files map[string][]byte
+	// Unconverted are the messages left out of the bundles.
+	Unconverted []UnconvertedMessage
+}
+
+// UnconvertedMessage is a message with no equivalent in the export format.
+type UnconvertedMessage struct {
+	Lang string
+	ID   string
+	Err  error
+}
+
+func (m UnconvertedMessage) Error() string {
+	return fmt.Sprintf("message %q in %s: %v", m.ID, m.Lang, m.Err)
+}
+
+// Export converts translations to a JSON bundle per language for format.
+// Template fields become placeholders of the format and custom templates become plural or select forms
+// where they map cleanly. Messages that can't be converted are reported in ExportResult.Unconverted.
+func Export(data *templates.TemplateData, format ExportFormat) (*ExportResult, error) {
+	if data == nil {
+		return nil, fmt.Errorf("data must be non-nil")
+	}
+	if format != ExportI18next && format != ExportFormatJS {
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+
+	res := &ExportResult{Files: make(map[string][]byte)}
+	manifest := make(map[string]string, len(data.Translations))
+	for _, tr := range data.Translations {
+		tag, err := language.Parse(tr.Lang)
+		if err != nil {
+			return nil, fmt.Errorf("invalid language %q: %w", tr.Lang, err)
+		}
+		bundle := make(map[string]string, len(tr.Messages))
+		for _, msg := range tr.Messages {
+			c := &exportConverter{format: format, tag: tag, vars: make(map[string]templates.VarData, len(msg.Vars))}
+			for _, v := range msg.Vars {
+				c.vars[v.Name] = v
+			}
+			entries, err := c.message(msg)
+			if err != nil {
+				res.Unconverted = append(res.Unconverted, UnconvertedMessage{Lang: tr.Lang, ID: msg.ID, Err: err})
+				continue
+			}
+			for k, v := range entries {
+				bundle[k] = v
+			}
+		}
+
+		b, err := marshalBundle(bundle)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(b)
+		name := tr.Lang + "." + hex.EncodeToString(sum[:4]) + ".json"
+		res.Files[name] = b
+		manifest[tr.Lang] = name
+	}
+
+	b, err := marshalBundle(manifest)
+	if err != nil {
+		return nil, err
+	}
+	res.Files[ExportManifest] = b
+
+	return res, nil
+}
+
+// marshalBundle returns indented JSON with sorted keys, leaving HTML unescaped.
+func marshalBundle(v map[string]string) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("error encoding bundle: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// exportConverter converts the messages of a language to an export format.
+type exportConverter struct {
+	format ExportFormat
+	tag    language.Tag
+	vars   map[string]templates.VarData // by variable name
+	// inPlural reports whether an ICU plural branch is being written, where # is special.
+	inPlural bool
+}
+
+// exportCase is a custom template matching variable Var when it equals Value,
+// or when its plural form is Form for templates of plural and ordinal blocks.
+type exportCase struct {
+	Var      templates.VarData
+	Value    constant.Value
+	Form     string
+	Ordinal  bool
+	Template string
+}
+
+// message returns the bundle entries of msg by key.
+func (c *exportConverter) message(msg templates.MessageData) (map[string]string, error) {
+	var cases []exportCase
+	for _, ct := range msg.CustomTemplates {
+		cs, err := c.exportCase(ct)
+		if err != nil {
+			return nil, err
+		}
+		if len(cases) > 0 && cs.Var.Name != cases[0].Var.Name {
+			return nil, fmt.Errorf("custom templates compare different variables %s and %s", cases[0].Var.Param, cs.Var.Param)
+		}
+		cases = append(cases, cs)
+	}
+
+	if len(cases) == 0 {
+		s, err := c.template(msg.Template)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{msg.ID: s}, nil
+	}
+
+	if c.format == ExportI18next {
+		return c.i18nextPlurals(msg, cases)
+	}
+
+	return c.icuCases(msg, cases)
+}
+
+// exportCase converts a custom template expression comparing a single variable with a literal,
+// or testing a bool variable.
+func (c *exportConverter) exportCase(ct templates.CustomTemplate) (exportCase, error) {
+	if ct.Plural != nil {
+		return exportCase{Var: c.vars[ct.Plural.Variable], Form: ct.Plural.Form, Ordinal: ct.Plural.Ordinal, Template: ct.Template}, nil
+	}
+
+	unsupported := fmt.Errorf("custom template expression %q has no %s equivalent", ct.Expression, c.format)
+	expr, err := parser.ParseExpr(ct.Expression)
+	if err != nil {
+		return exportCase{}, unsupported
+	}
+
+	var ident *ast.Ident
+	var value constant.Value
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		ident, value = e, constant.MakeBool(true)
+	case *ast.UnaryExpr:
+		if e.Op == token.NOT {
+			ident, _ = ast.Unparen(e.X).(*ast.Ident)
+			value = constant.MakeBool(false)
+		}
+	case *ast.BinaryExpr:
+		if e.Op != token.EQL {
+			break
+		}
+		x, y := ast.Unparen(e.X), ast.Unparen(e.Y)
+		if _, ok := x.(*ast.BasicLit); ok {
+			x, y = y, x
+		}
+		lit, ok := y.(*ast.BasicLit)
+		if !ok {
+			break
+		}
+		ident, _ = x.(*ast.Ident)
+		value = constant.MakeFromLiteral(lit.Value, lit.Kind, 0)
+	}
+	if ident == nil {
+		return exportCase{}, unsupported
+	}
+
+	for _, v := range c.vars {
+		if v.Param != ident.Name {
+			continue
+		}
+		switch {
+		case v.Type == "bool" && value.Kind() == constant.Bool,
+			v.Type == "string" && value.Kind() == constant.String,
+			isGoInteger(v.Type) && value.Kind() == constant.Int:
+			return exportCase{Var: v, Value: value, Template: ct.Template}, nil
+		}
+	}
+
+	return exportCase{}, unsupported
+}
+
+// i18nextPlurals returns a key per plural category of the language, for plural block forms and custom
+// templates matching count == 0, which i18next selects with the _zero suffix, and count == 1 when it is
+// the only number in the language's "one" category.
+func (c *exportConverter) i18nextPlurals(msg templates.MessageData, cases []exportCase) (map[string]string, error) {
+	v := cases[0].Var
+	if v.Param != "count" || !isGoInteger(v.Type) {
+		return nil, fmt.Errorf("custom templates compare %s, but i18next plurals require an integer count variable", v.Param)
+	}
+
+	// i18next selects ordinal forms with _ordinal_ suffixes, given the ordinal option.
+	ordinal := slices.ContainsFunc(cases, func(cs exportCase) bool { return cs.Ordinal })
+	rules, prefix := plural.Cardinal, "_"
+	if ordinal {
+		rules, prefix = plural.Ordinal, "_ordinal_"
+	}
+	categories := load.PluralCategories(rules, c.tag)
+	dft, err := c.template(msg.Template)
+	if err != nil {
+		return nil, err
+	}
+	entries := make(map[string]string, len(categories)+1)
+	for form := range categories {
+		entries[msg.ID+prefix+load.PluralFormName(form)] = dft
+	}
+
+	seen := make(map[string]bool, len(cases))
+	for _, cs := range cases {
+		suffix := prefix + cs.Form
+		if cs.Form == "" {
+			switch n, _ := constant.Int64Val(cs.Value); {
+			// i18next selects _zero for 0 in every language
+			case !ordinal && n == 0 && (categories[plural.Zero] == nil || onlyPluralForm(categories, plural.Zero, 0)):
+				suffix = "_zero"
+			case !ordinal && n == 1 && onlyPluralForm(categories, plural.One, 1):
+				suffix = "_one"
+			default:
+				return nil, fmt.Errorf("custom template for count == %s has no i18next plural suffix in %s", cs.Value, c.tag)
+			}
+		}
+		if seen[suffix] {
+			continue // shadowed by a previous custom template
+		}
+		seen[suffix] = true
+		s, err := c.template(cs.Template)
+		if err != nil {
+			return nil, err
+		}
+		entries[msg.ID+suffix] = s
+	}
+
+	return entries, nil
+}
+
+// icuCases returns an ICU plural message for integers, or a select message for strings and bools.
+func (c *exportConverter) icuCases(msg templates.MessageData, cases []exportCase) (map[string]string, error) {
+	v := cases[0].Var
+	kind := "select"
+	if isGoInteger(v.Type) {
+		kind = "plural"
+		if slices.ContainsFunc(cases, func(cs exportCase) bool { return cs.Ordinal }) {
+			kind = "selectordinal"
+		}
+		c.inPlural = true
+		defer func() { c.inPlural = false }()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "{%s, %s,", v.Param, kind)
+	seen := make(map[string]bool, len(cases))
+	for _, cs := range cases {
+		var key string
+		switch {
+		case cs.Form != "":
+			key = cs.Form
+		case cs.Value.Kind() == constant.Int:
+			key = "=" + cs.Value.ExactString()
+		case cs.Value.Kind() == constant.Bool:
+			key = cs.Value.ExactString()
+		default:
+			key = constant.StringVal(cs.Value)
+			if key == "other" || !icuSelectKey.MatchString(key) {
+				return nil, fmt.Errorf("%q is not a valid ICU select key", key)
+			}
+		}
+		if seen[key] {
+			continue // shadowed by a previous custom template
+		}
+		seen[key] = true
+		s, err := c.template(cs.Template)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&b, " %s {%s}", key, s)
+	}
+	dft, err := c.template(msg.Template)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(&b, " other {%s}}", dft)
+
+	return map[string]string{msg.ID: b.String()}, nil
+}
+
+var icuSelectKey = regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)
+
+// template converts a template of text, fields and, for ICU messages, conditions on bool fields.
+func (c *exportConverter) template(tpl string) (string, error) {
+	tree := parse.New("")
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse(tpl, "", "", map[string]*parse.Tree{}); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := c.list(&b, tree.Root); err != nil {
+		return "", fmt.Errorf("template %q: %w", tpl, err)
+	}
+
+	return b.String(), nil
+}
+
+func (c *exportConverter) list(b *strings.Builder, list *parse.ListNode) error {
+	if list == nil {
+		return nil
+	}
+	for _, node := range list.Nodes {
+		if err := c.node(b, node); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *exportConverter) node(b *strings.Builder, node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.TextNode:
+		return c.text(b, string(n.Text))
+	case *parse.ActionNode:
+		if len(n.Pipe.Decl) > 0 || len(n.Pipe.Cmds) != 1 || len(n.Pipe.Cmds[0].Args) != 1 {
+			return fmt.Errorf("unsupported action %s", n)
+		}
+		v, err := c.field(n.Pipe.Cmds[0].Args[0])
+		if err != nil {
+			return err
+		}
+		if c.format == ExportI18next {
+			fmt.Fprintf(b, "{{%s}}", v.Param)
+		} else {
+			fmt.Fprintf(b, "{%s}", v.Param)
+		}
+	case *parse.IfNode:
+		if c.format == ExportI18next {
+			return fmt.Errorf("unsupported condition %s: i18next has no select form", n.Pipe)
+		}
+		if len(n.Pipe.Decl) > 0 || len(n.Pipe.Cmds) != 1 || len(n.Pipe.Cmds[0].Args) != 1 {
+			return fmt.Errorf("unsupported condition %s", n.Pipe)
+		}
+		v, err := c.field(n.Pipe.Cmds[0].Args[0])
+		if err != nil {
+			return err
+		}
+		if v.Type != "bool" {
+			return fmt.Errorf("unsupported condition %s on %s", n.Pipe, v.Type)
+		}
+		fmt.Fprintf(b, "{%s, select, true {", v.Param)
+		if err := c.list(b, n.List); err != nil {
+			return err
+		}
+		b.WriteString("} other {")
+		if err := c.list(b, n.ElseList); err != nil {
+			return err
+		}
+		b.WriteString("}}")
+	default:
+		return fmt.Errorf("unsupported template construct %s", n)
+	}
+
+	return nil
+}
+
+func (c *exportConverter) field(node parse.Node) (templates.VarData, error) {
+	f, ok := node.(*parse.FieldNode)
+	if !ok || len(f.Ident) != 1 {
+		return templates.VarData{}, fmt.Errorf("unsupported command %s", node)
+	}
+	v, ok := c.vars[f.Ident[0]]
+	if !ok {
+		return templates.VarData{}, fmt.Errorf("unknown field %s", f)
+	}
+
+	return v, nil
+}
+
+// text writes literal text, quoting ICU syntax characters with apostrophes.
+func (c *exportConverter) text(b *strings.Builder, text string) error {
+	if c.format == ExportI18next {
+		if strings.Contains(text, "{{") || strings.Contains(text, "}}") || strings.Contains(text, "$t(") {
+			return fmt.Errorf("text %q contains i18next interpolation syntax", text)
+		}
+		b.WriteString(text)
+		return nil
+	}
+
+	special := func(r rune) bool {
+		return r == '{' || r == '}' || r == '<' || r == '#' && c.inPlural
+	}
+	quoted := false
+	for _, r := range text {
+		switch {
+		case special(r) && !quoted:
+			b.WriteByte('\'')
+			quoted = true
+		case !special(r) && quoted:
+			b.WriteByte('\'')
+			quoted = false
+		}
+		if r == '\'' {
+			b.WriteString("''")
+			continue
+		}
+		b.WriteRune(r)
+	}
+	if quoted {
+		b.WriteByte('\'')
+	}
+
+	return nil
+}
+
+// onlyPluralForm reports whether form applies to n alone.
+func onlyPluralForm(categories map[plural.Form][]int, form plural.Form, n int) bool {
+	ints := categories[form]
+
+	return len(ints) == 1 && ints[0] == n
+}
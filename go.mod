@@ -6,18 +6,17 @@ require (
 	github.com/a-h/templ v0.2.778
 	github.com/google/go-cmp v0.6.0
 	github.com/kenshaw/snaker v0.3.0
+	github.com/rivo/uniseg v0.4.7
 	github.com/stretchr/testify v1.9.0
 	golang.org/x/text v0.18.0
-	golang.org/x/tools v0.24.0
+	golang.org/x/tools v0.26.0
 	gopkg.in/yaml.v3 v3.0.1
 	mvdan.cc/gofumpt v0.7.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/kofalt/go-memoize v0.0.0-20240506050413-9e5eb99a0f2a // indirect
-	github.com/patrickmn/go-cache v2.1.0+incompatible // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	golang.org/x/mod v0.20.0 // indirect
+	golang.org/x/mod v0.21.0 // indirect
 	golang.org/x/sync v0.8.0 // indirect
 )
@@ -0,0 +1,164 @@
+// Package i18ndev serves translation files to development translators generated by i18ngo.WithDevTranslators.
+// Generated code imports it instead of the generator, so it must not depend on it.
+package i18ndev
+
+import (
+	"fmt"
+	"html/template"
+	"io/fs"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/danicc097/i18ngo/internal/load"
+	"github.com/danicc097/i18ngo/templates"
+	"golang.org/x/text/language"
+)
+
+// DefaultCheckInterval is how often a Source checks translation files for changes by default.
+const DefaultCheckInterval = time.Second
+
+// Source serves message templates read from translation files at runtime,
+// reloading them whenever the files change.
+// It is meant for local development only.
+type Source struct {
+	fsys     fs.FS
+	path     string
+	interval time.Duration
+
+	mu       sync.Mutex
+	checked  time.Time
+	stamp    string
+	messages map[devMessageKey]*devMessage
+	err      error
+}
+
+type devMessageKey struct {
+	lang  string
+	msgID string
+}
+
+// devMessage holds the parsed templates of a message in a language.
+type devMessage struct {
+	// conditions are the conditions of custom templates in the translation file, in order.
+	conditions []string
+	// templates are templates by condition, the default template under "".
+	templates map[string]*template.Template
+}
+
+// Option configures a Source.
+type Option func(*Source)
+
+// WithCheckInterval sets how often translation files are checked for changes,
+// DefaultCheckInterval by default. Zero checks them on every render.
+func WithCheckInterval(d time.Duration) Option {
+	return func(s *Source) {
+		s.interval = d
+	}
+}
+
+// NewSource returns a source for the translation files in the given path of fsys.
+func NewSource(fsys fs.FS, path string, opts ...Option) *Source {
+	s := &Source{fsys: fsys, path: path, interval: DefaultCheckInterval}
+	for _, o := range opts {
+		o(s)
+	}
+	return s
+}
+
+// Template returns the parsed template of a message for a language: the custom template with the given
+// condition, as returned by templates.CustomTemplate.Condition, or the default template if condition is empty.
+// conditions are those of the custom templates translators were generated with, in order.
+// It fails if the translation file doesn't declare the same ones, since translators would select another template.
+func (s *Source) Template(lang, msgID, condition string, conditions ...string) (*template.Template, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.reload(); err != nil {
+		return nil, err
+	}
+	msg, ok := s.messages[devMessageKey{lang: lang, msgID: msgID}]
+	if !ok {
+		return nil, fmt.Errorf("message %q not found for %s: regenerate translators", msgID, lang)
+	}
+	if !slices.Equal(msg.conditions, conditions) {
+		return nil, fmt.Errorf("custom templates of message %q in %s are selected by %q instead of %q: regenerate translators",
+			msgID, lang, msg.conditions, conditions)
+	}
+
+	return msg.templates[condition], nil
+}
+
+// reload loads translation files again if any was added, removed or modified since the last load,
+// checking them at most once per interval.
+func (s *Source) reload() error {
+	now := time.Now()
+	if s.messages != nil && now.Sub(s.checked) < s.interval {
+		return s.err
+	}
+	s.checked = now
+
+	stamp, err := s.fingerprint()
+	if err != nil {
+		return fmt.Errorf("error reading translation files: %w", err)
+	}
+	if s.messages != nil && stamp == s.stamp {
+		return s.err
+	}
+	s.stamp = stamp
+	s.messages, s.err = s.load()
+
+	return s.err
+}
+
+func (s *Source) fingerprint() (string, error) {
+	var b strings.Builder
+	err := fs.WalkDir(s.fsys, s.path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !strings.HasSuffix(p, ".i18ngo.yaml") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "%s:%d:%s\n", p, info.Size(), info.ModTime().Format(time.RFC3339Nano))
+
+		return nil
+	})
+
+	return b.String(), err
+}
+
+func (s *Source) load() (map[devMessageKey]*devMessage, error) {
+	langs, err := load.Translations(s.fsys, s.path)
+	if err != nil {
+		return map[devMessageKey]*devMessage{}, err
+	}
+
+	messages := make(map[devMessageKey]*devMessage)
+	for lang, translations := range langs {
+		for msgID, msg := range translations.Messages {
+			funcs := templates.Funcs(language.Make(lang))
+			tmpl, err := template.New(msgID).Funcs(funcs).Parse(msg.Template)
+			if err != nil {
+				return map[devMessageKey]*devMessage{}, fmt.Errorf("%s: error parsing template of message %q: %w", lang, msgID, err)
+			}
+			m := &devMessage{templates: map[string]*template.Template{"": tmpl}}
+			for i, ct := range msg.CustomTemplates {
+				tmpl, err := template.New(fmt.Sprintf("%sCustom%d", msgID, i)).Funcs(funcs).Parse(ct.Template)
+				if err != nil {
+					return map[devMessageKey]*devMessage{}, fmt.Errorf("%s: error parsing custom template %d of message %q: %w", lang, i, msgID, err)
+				}
+				m.conditions = append(m.conditions, ct.Condition())
+				m.templates[ct.Condition()] = tmpl
+			}
+			messages[devMessageKey{lang: lang, msgID: msgID}] = m
+		}
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,21 @@
+// Package i18nrt holds the types generated code uses at runtime.
+// It has no dependencies, so that generated code doesn't depend on the generator.
+package i18nrt
+
+// Money is an exact amount of a currency, for message variables formatted with the currency template function.
+type Money struct {
+	// Amount is in the ISO 4217 minor unit of Currency, e.g. cents for USD.
+	Amount int64
+	// Currency is an ISO 4217 code, e.g. "EUR".
+	Currency string
+}
+
+// MinorUnits returns the amount of m in the minor unit of its currency.
+func (m Money) MinorUnits() int64 {
+	return m.Amount
+}
+
+// CurrencyCode returns the ISO 4217 code of the currency of m.
+func (m Money) CurrencyCode() string {
+	return m.Currency
+}
@@ -0,0 +1,56 @@
+// Package load reads translation files for both the generator and the runtime of generated code,
+// so it must not depend on the generator.
+package load
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/danicc097/i18ngo/templates"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+// Translations returns the translation files in the given path of fsys by language,
+// with plural and ordinal blocks expanded into custom templates.
+func Translations(fsys fs.FS, path string) (map[string]templates.Translations, error) {
+	if err := ValidateStructure(fsys, path); err != nil {
+		return nil, fmt.Errorf("error validating translation files: %w", err)
+	}
+
+	translations := make(map[string]templates.Translations)
+	err := fs.WalkDir(fsys, path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasSuffix(p, ".i18ngo.yaml") {
+			file, err := fs.ReadFile(fsys, p)
+			if err != nil {
+				return err
+			}
+			var t templates.Translations
+			if err := yaml.Unmarshal(file, &t); err != nil {
+				return err
+			}
+			tlFile := p[strings.LastIndex(p, "/")+1:]
+			lang := strings.Split(tlFile, ".i18ngo.yaml")[0]
+			if _, err = language.Parse(lang); err != nil {
+				return fmt.Errorf("invalid locale %s: %w", lang, err)
+			}
+			for msgID, msg := range t.Messages {
+				if t.Messages[msgID], err = expandPlural(lang, msg); err != nil {
+					return fmt.Errorf("invalid plural of message %q in %s: %w", msgID, lang, err)
+				}
+			}
+			translations[lang] = t
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return translations, nil
+}
@@ -0,0 +1,122 @@
+package load
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/danicc097/i18ngo/templates"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// pluralFormNames are the CLDR plural forms, in the order their custom templates are checked.
+var pluralFormNames = []string{"zero", "one", "two", "few", "many", "other"}
+
+// PluralBlock returns the plural or ordinal block of msg, if any, with its key and the rules selecting its forms.
+func PluralBlock(msg templates.Message) (key string, p *templates.Plural, rules *plural.Rules) {
+	if msg.Ordinal != nil {
+		return "ordinal", msg.Ordinal, plural.Ordinal
+	}
+
+	return "plural", msg.Plural, plural.Cardinal
+}
+
+// expandPlural returns msg with the templates of its plural or ordinal block as custom templates selected by
+// the plural form of the block variable in lang, and the other form as its template.
+// The block must define exactly the forms integers take in lang.
+func expandPlural(lang string, msg templates.Message) (templates.Message, error) {
+	if msg.Plural != nil && msg.Ordinal != nil {
+		return msg, errors.New("plural and ordinal can't both be set")
+	}
+	key, p, rules := PluralBlock(msg)
+	if p == nil {
+		return msg, nil
+	}
+	if msg.Template != "" {
+		return msg, fmt.Errorf("template can't be set along with %s, use %[1]s.other instead", key)
+	}
+	if _, ok := msg.Variables[p.Variable]; !ok {
+		return msg, fmt.Errorf("%s variable %q is not declared", key, p.Variable)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(p.Forms)) {
+		if !slices.Contains(pluralFormNames, name) {
+			return msg, fmt.Errorf("unknown %s form %q", key, name)
+		}
+	}
+
+	needed := map[string]bool{"other": true}
+	for form := range PluralCategories(rules, language.Make(lang)) {
+		needed[PluralFormName(form)] = true
+	}
+	want := []string{}
+	for _, name := range pluralFormNames {
+		if needed[name] {
+			want = append(want, name)
+		}
+	}
+	got := []string{}
+	for _, name := range pluralFormNames {
+		if _, ok := p.Forms[name]; ok {
+			got = append(got, name)
+		}
+	}
+	if !slices.Equal(got, want) {
+		return msg, fmt.Errorf("%s forms in %s must be %s, got %s", key, lang, strings.Join(want, ", "), strings.Join(got, ", "))
+	}
+
+	msg.Template = p.Forms["other"]
+	msg.CustomTemplates = slices.Clone(msg.CustomTemplates)
+	for _, name := range got[:len(got)-1] {
+		msg.CustomTemplates = append(msg.CustomTemplates, templates.CustomTemplate{
+			Template: p.Forms[name],
+			Plural:   &templates.PluralCase{Variable: p.Variable, Form: name, Ordinal: key == "ordinal"},
+		})
+	}
+
+	return msg, nil
+}
+
+// PluralCategories returns the plural forms of integers in a language by cardinal or ordinal rules,
+// with the integers up to 1000 they apply to.
+func PluralCategories(rules *plural.Rules, tag language.Tag) map[plural.Form][]int {
+	categories := make(map[plural.Form][]int)
+	for i := 0; i <= 1000; i++ {
+		form := rules.MatchPlural(tag, i, 0, 0, 0, 0)
+		categories[form] = append(categories[form], i)
+	}
+
+	return categories
+}
+
+// PluralOperand returns the integer digits rules match the plural form of n by, as generated pluralForm does:
+// beyond the int32 range, its last seven digits kept out of the range of exact values.
+func PluralOperand(n uint64) int {
+	if n > math.MaxInt32 {
+		n = n%10_000_000 + 10_000_000
+	}
+
+	return int(n)
+}
+
+// PluralFormName returns the name of a plural form in translation files.
+func PluralFormName(form plural.Form) string {
+	switch form {
+	case plural.Zero:
+		return "zero"
+	case plural.One:
+		return "one"
+	case plural.Two:
+		return "two"
+	case plural.Few:
+		return "few"
+	case plural.Many:
+		return "many"
+	}
+
+	return "other"
+}
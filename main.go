@@ -4,20 +4,27 @@ import (
 	"bytes"
 	"embed"
 	"fmt"
-	"html/template"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	gotypes "go/types"
 	"io/fs"
 	"regexp"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"golang.org/x/text/language"
+	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/imports"
 	"mvdan.cc/gofumpt/format"
 
+	"github.com/danicc097/i18ngo/internal/load"
 	"github.com/danicc097/i18ngo/templates"
 	"github.com/danicc097/i18ngo/validator"
 	"github.com/kenshaw/snaker"
-	"gopkg.in/yaml.v3"
 )
 
 type LanguageLoader struct {
@@ -25,69 +32,180 @@ type LanguageLoader struct {
 }
 
 func NewLanguageLoader(fsys fs.FS, path string) (*LanguageLoader, error) {
-	loader := &LanguageLoader{translations: make(map[string]templates.Translations)}
-
-	if err := validator.ValidateTranslationFiles(fsys, path); err != nil {
-		return nil, fmt.Errorf("error validating translation files: %w", err)
-	}
-	err := fs.WalkDir(fsys, path, func(p string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-		if strings.HasSuffix(p, ".i18ngo.yaml") {
-			file, err := fs.ReadFile(fsys, p)
-			if err != nil {
-				return err
-			}
-			var t templates.Translations
-			if err := yaml.Unmarshal(file, &t); err != nil {
-				return err
-			}
-			tlFile := p[strings.LastIndex(p, "/")+1:]
-			lang := strings.Split(tlFile, ".i18ngo.yaml")[0]
-			if _, err = language.Parse(lang); err != nil {
-				return fmt.Errorf("invalid locale %s: %w", lang, err)
-			}
-			loader.translations[lang] = t
-		}
-
-		return nil
-	})
+	translations, err := load.Translations(fsys, path)
 	if err != nil {
 		return nil, err
 	}
 
-	return loader, nil
+	return &LanguageLoader{translations: translations}, nil
 }
 
-//go:embed templates/template.go.tpl
+//go:embed templates/template.go.tpl templates/typescript.ts.tpl
 var templateFS embed.FS
 
 type GenerateOption func(*generateOptions)
 
 type generateOptions struct {
-	WithCustomTemplate bool
+	CompiledTemplates bool
+	ArgsStructs       bool
+	BaseLang          string
+	LazyTemplates     bool
+	DevTranslators    bool
+	LocaleBuildTags   bool
+	Target            Target
+	Backend           templates.Backend
+	TemplComponents   bool
+	LangMatcher       bool
+	LangMiddleware    bool
+	Render            bool
+	Memoized          bool
+	TestTranslators   bool
+	// UnverifiedExamples allows examples that can't be evaluated at generation time.
+	UnverifiedExamples bool
+}
+
+// WithFilesystemTemplate generates Go code by executing templates/template.go.tpl in fsys
+// instead of the default one, e.g. to extend it.
+func WithFilesystemTemplate(fsys fs.FS) GenerateOption {
+	return func(opts *generateOptions) {
+		opts.Backend = textTemplateBackend{fsys: fsys}
+	}
+}
+
+// WithCompiledTemplates generates plain Go code for templates using only text, fields,
+// if/else and printf, avoiding template execution at runtime.
+// Templates using any other construct are still executed as templates.
+func WithCompiledTemplates() GenerateOption {
+	return func(opts *generateOptions) {
+		opts.CompiledTemplates = true
+	}
+}
+
+// WithBaseLang sets the fallback language used when none is set in a context.
+// It defaults to the first language in alphabetical order.
+func WithBaseLang(lang string) GenerateOption {
+	return func(opts *generateOptions) {
+		opts.BaseLang = lang
+	}
+}
+
+// WithLazyTemplates parses templates on first use of each language and message
+// instead of at initialization, and generates NewTranslatorsE and Preload
+// for callers that prefer to fail fast.
+func WithLazyTemplates() GenerateOption {
+	return func(opts *generateOptions) {
+		opts.LazyTemplates = true
+	}
+}
+
+// WithDevTranslators generates NewDevTranslators, which returns translators
+// reading templates from translation files at runtime for local development.
+// Generated code then depends on this module.
+func WithDevTranslators() GenerateOption {
+	return func(opts *generateOptions) {
+		opts.DevTranslators = true
+	}
+}
+
+// WithLocaleBuildTags makes GenerateFiles constrain each locale file except the base language's
+// with a build tag such as i18n_es, so that only locales built with their tag are compiled in.
+func WithLocaleBuildTags() GenerateOption {
+	return func(opts *generateOptions) {
+		opts.LocaleBuildTags = true
+	}
+}
+
+// WithBackend sets the backend rendering generated Go code. It defaults to BackendTextTemplate.
+func WithBackend(backend templates.Backend) GenerateOption {
+	return func(opts *generateOptions) {
+		opts.Backend = backend
+	}
+}
+
+// WithTemplComponents generates a templ.Component per message, e.g. MyGreetingC(count, name),
+// rendering the message with the translator for the language carried by the context.
+// Generated code then depends on github.com/a-h/templ.
+func WithTemplComponents() GenerateOption {
+	return func(opts *generateOptions) {
+		opts.TemplComponents = true
+	}
+}
+
+// WithMemoizedTranslators generates NewMemoizedTranslators and NewMemoizedTranslator,
+// wrapping translators with a size-bounded LRU cache of rendered messages.
+func WithMemoizedTranslators() GenerateOption {
+	return func(opts *generateOptions) {
+		opts.Memoized = true
+	}
 }
 
-func WithFilesystemTemplate() GenerateOption {
+// WithTestTranslators generates NewKeyTranslator and NewRecordingTranslator, test doubles
+// rendering message ids and arguments instead of translated text.
+func WithTestTranslators() GenerateOption {
 	return func(opts *generateOptions) {
-		opts.WithCustomTemplate = true
+		opts.TestTranslators = true
 	}
 }
 
-func Generate(data *templates.TemplateData) ([]byte, error) {
+// WithRender generates Render, rendering messages by MessageID with arguments keyed by variable name,
+// and the errors it returns.
+func WithRender() GenerateOption {
+	return func(opts *generateOptions) {
+		opts.Render = true
+	}
+}
+
+// WithLangMatcher generates Matcher, MatchLang and ParseLang, mapping language tags and
+// Accept-Language headers onto available languages.
+func WithLangMatcher() GenerateOption {
+	return func(opts *generateOptions) {
+		opts.LangMatcher = true
+	}
+}
+
+// WithLangMiddleware generates LangMiddleware, which stores the language of each request in its context
+// for T, and its LangSource constructors. It implies WithLangMatcher. Generated code then imports net/http.
+func WithLangMiddleware() GenerateOption {
+	return func(opts *generateOptions) {
+		opts.LangMatcher = true
+		opts.LangMiddleware = true
+	}
+}
+
+// WithUnverifiedExamples allows examples that can't be evaluated at generation time, such as those
+// of variables with methods or of custom template expressions calling functions, leaving them to the
+// generated table test instead of failing. They are listed by TemplateData.UnverifiedExamples.
+func WithUnverifiedExamples() GenerateOption {
+	return func(opts *generateOptions) {
+		opts.UnverifiedExamples = true
+	}
+}
+
+// WithArgsStructs generates a MyGreetingArgs struct per message, used as the
+// single argument of its method instead of alphabetically ordered positional parameters.
+func WithArgsStructs() GenerateOption {
+	return func(opts *generateOptions) {
+		opts.ArgsStructs = true
+	}
+}
+
+// Generate generates Go code for data, or TypeScript code if data.Target is TargetTypeScript.
+// Options other than WithBackend and WithFilesystemTemplate apply to GetTranslationData only.
+func Generate(data *templates.TemplateData, opts ...GenerateOption) ([]byte, error) {
 	if data == nil {
 		return nil, fmt.Errorf("data must be non-nil")
 	}
-	// gotempl
-	/* var buf bytes.Buffer
-	 component := templates.TranslationCode(data)
-	err := component.Render(context.Background(), &buf)
-	if err != nil {
-		return nil, fmt.Errorf("error rendering template: %w", err)
-	} */
 
-	src, err := generateWithGoTemplate(data)
+	if err := checkTarget(Target(data.Target)); err != nil {
+		return nil, err
+	}
+	if Target(data.Target) == TargetTypeScript {
+		return generateTypeScript(data)
+	}
+
+	src, err := generateGo(func(buf *bytes.Buffer) error {
+		return backend(opts).Translations(buf, data)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -95,27 +213,78 @@ func Generate(data *templates.TemplateData) ([]byte, error) {
 	return src, nil
 }
 
-func generateWithGoTemplate(data *templates.TemplateData) ([]byte, error) {
-	funcMap := template.FuncMap{
-		"camelCase": func(s string) string {
-			return snaker.ForceLowerCamelIdentifier(s)
-		},
-		"pascalCase": func(s string) string {
-			return snaker.ForceCamelIdentifier(s)
-		},
+// GenerateFiles generates shared declarations in i18n.go, translators in a file per locale,
+// e.g. i18n_es.go, and message examples in i18n_test.go, keyed by file name.
+// NewTranslators only includes the locales compiled in.
+func GenerateFiles(data *templates.TemplateData, opts ...GenerateOption) (map[string][]byte, error) {
+	if data == nil {
+		return nil, fmt.Errorf("data must be non-nil")
+	}
+
+	shared := *data
+	shared.SplitLocales = true
+	src, err := generateGo(func(buf *bytes.Buffer) error {
+		return backend(opts).Translations(buf, &shared)
+	})
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{"i18n.go": src}
+
+	for _, tr := range data.Translations {
+		suffix := strings.ReplaceAll(strings.ToLower(tr.Lang), "-", "_")
+		locale := templates.LocaleFileData{Root: &shared, Translation: tr}
+		if data.LocaleBuildTags && tr.Lang != data.BaseLang.Lang {
+			locale.BuildTag = "i18n_" + suffix
+		}
+		src, err := generateGo(func(buf *bytes.Buffer) error {
+			return backend(opts).Locale(buf, locale)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error generating %s translators: %w", tr.Lang, err)
+		}
+		files["i18n_"+suffix+".go"] = src
+	}
+
+	tests, err := GenerateTests(data, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error generating tests: %w", err)
+	}
+	if tests != nil {
+		files["i18n_test.go"] = tests
+	}
+
+	return files, nil
+}
+
+// GenerateTests generates a table test rendering message examples with NewTranslators,
+// or nil if no message has examples.
+func GenerateTests(data *templates.TemplateData, opts ...GenerateOption) ([]byte, error) {
+	if data == nil {
+		return nil, fmt.Errorf("data must be non-nil")
+	}
+	if !data.HasExamples() {
+		return nil, nil
 	}
 
-	var tplFsys fs.FS = templateFS
-	tmpl := template.Must(template.New("template.go.tpl").Funcs(funcMap).ParseFS(tplFsys, "templates/template.go.tpl"))
+	return generateGo(func(buf *bytes.Buffer) error {
+		return backend(opts).ExamplesTest(buf, data)
+	})
+}
 
+// generateGo renders Go code with render and formats it.
+func generateGo(render func(buf *bytes.Buffer) error) ([]byte, error) {
 	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
+	if err := render(&buf); err != nil {
 		return []byte{}, fmt.Errorf("error executing template: %w", err)
 	}
 
-	source := buf.Bytes()
+	source, err := removeUnusedImports(buf.Bytes())
+	if err != nil {
+		return []byte{}, fmt.Errorf("error removing unused imports: %w", err)
+	}
 
-	source, err := format.Source(source, format.Options{})
+	source, err = format.Source(source, format.Options{})
 	if err != nil {
 		return []byte{}, fmt.Errorf("error formatting generated Go code: %w", err)
 	}
@@ -131,18 +300,101 @@ func generateWithGoTemplate(data *templates.TemplateData) ([]byte, error) {
 	return source, nil
 }
 
+// removeUnusedImports deletes imports the generated code does not reference, and repeated imports,
+// so that templates may import everything optional features could need.
+func removeUnusedImports(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	f.Imports = f.Imports[:0]
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		specs := gen.Specs[:0]
+		for _, spec := range gen.Specs {
+			spec := spec.(*ast.ImportSpec)
+			if key := importName(spec) + " " + spec.Path.Value; !seen[key] {
+				seen[key] = true
+				specs = append(specs, spec)
+				f.Imports = append(f.Imports, spec)
+			}
+		}
+		gen.Specs = specs
+	}
+
+	specs := append([]*ast.ImportSpec{}, f.Imports...)
+	for _, spec := range specs {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name == nil && !token.IsIdentifier(name) {
+			continue // package name can't be guessed from its path
+		}
+		if !astutil.UsesImport(f, path) {
+			astutil.DeleteNamedImport(fset, f, importName(spec), path)
+		}
+	}
+
+	var buf bytes.Buffer
+	cfg := printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 8}
+	if err := cfg.Fprint(&buf, fset, f); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func importName(spec *ast.ImportSpec) string {
+	if spec.Name == nil {
+		return ""
+	}
+	return spec.Name.Name
+}
+
 var tmplVarRe = regexp.MustCompile(`{{\s*\.\s*([\w\.]+)\s*}}`)
 
 func extractTemplateVariables(tplStr string) ([]string, error) {
 	matches := tmplVarRe.FindAllStringSubmatch(tplStr, -1)
 	var vars []string
 	for _, match := range matches {
-		vars = append(vars, match[1])
+		vars = append(vars, strings.Split(match[1], ".")[0]) // e.g. User in .User.Name
 	}
 	vars = unique(vars)
 	return vars, nil
 }
 
+// expressionIdents returns the identifiers referenced by a Go expression.
+func expressionIdents(expression string) []string {
+	expr, err := parser.ParseExpr(expression)
+	if err != nil {
+		return nil
+	}
+
+	var idents []string
+	var inspect func(n ast.Node) bool
+	inspect = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			ast.Inspect(n.X, inspect) // skip field and package member names
+			return false
+		case *ast.Ident:
+			idents = append(idents, n.Name)
+		}
+		return true
+	}
+	ast.Inspect(expr, inspect)
+
+	return unique(idents)
+}
+
 func unique[T comparable](input []T) []T {
 	m := make(map[T]bool)
 	var result []T
@@ -156,13 +408,14 @@ func unique[T comparable](input []T) []T {
 }
 
 // GetTranslationData retrieves data for translations in the given path in the filesystem.
-// Assumes the filesystem contains a templates/template.go.tpl file to generate from.
-// You may extend the default template as desired.
 func GetTranslationData(fsys fs.FS, path, pkgName string, opts ...GenerateOption) (*templates.TemplateData, error) {
 	optsMap := &generateOptions{}
 	for _, o := range opts {
 		o(optsMap)
 	}
+	if err := checkTarget(optsMap.Target); err != nil {
+		return nil, err
+	}
 
 	loader, err := NewLanguageLoader(fsys, path)
 	if err != nil {
@@ -170,10 +423,22 @@ func GetTranslationData(fsys fs.FS, path, pkgName string, opts ...GenerateOption
 	}
 
 	data := templates.TemplateData{
-		PkgName:      pkgName,
-		Messages:     make([]templates.MessageData, 0),
-		Translations: make([]templates.TranslationData, 0),
-		Langs:        make([]templates.LangData, 0),
+		PkgName:           pkgName,
+		Messages:          make([]templates.MessageData, 0),
+		Translations:      make([]templates.TranslationData, 0),
+		Langs:             make([]templates.LangData, 0),
+		CompiledTemplates: optsMap.CompiledTemplates,
+		ArgsStructs:       optsMap.ArgsStructs,
+		LazyTemplates:     optsMap.LazyTemplates,
+		DevTranslators:    optsMap.DevTranslators,
+		LocaleBuildTags:   optsMap.LocaleBuildTags,
+		Target:            string(optsMap.Target),
+		TemplComponents:   optsMap.TemplComponents,
+		LangMatcher:       optsMap.LangMatcher,
+		LangMiddleware:    optsMap.LangMiddleware,
+		Render:            optsMap.Render,
+		Memoized:          optsMap.Memoized,
+		TestTranslators:   optsMap.TestTranslators,
 	}
 
 	langKeys := make([]string, 0, len(loader.translations))
@@ -182,12 +447,28 @@ func GetTranslationData(fsys fs.FS, path, pkgName string, opts ...GenerateOption
 	}
 	sort.Strings(langKeys)
 
+	for _, lang := range langKeys {
+		data.Imports = append(data.Imports, loader.translations[lang].Imports...)
+	}
+	data.Imports = unique(data.Imports)
+	sort.Strings(data.Imports)
+
+	checker, err := validator.NewTypeChecker(data.Imports)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, lang := range langKeys {
 		translations := loader.translations[lang]
 		camelLang := snaker.SnakeToCamel(lang)
 		data.Langs = append(data.Langs, templates.LangData{CamelLang: camelLang, Lang: lang})
 
-		transData := templates.TranslationData{CamelLang: camelLang}
+		transData := templates.TranslationData{
+			CamelLang:      camelLang,
+			Lang:           lang,
+			LazyTemplates:  optsMap.LazyTemplates,
+			DevTranslators: optsMap.DevTranslators,
+		}
 
 		msgIDs := make([]string, 0, len(translations.Messages))
 		for msgID := range translations.Messages {
@@ -212,9 +493,7 @@ func GetTranslationData(fsys fs.FS, path, pkgName string, opts ...GenerateOption
 				}
 			}
 
-			// TODO: allow custom imports --> enables e.g. User.Username, User.Gender, etc.
-			// in the future for easier custom_templates.
-			exprVars := make([]string, 0, len(msg.Variables))
+			exprVars := checker.PackageNames()
 			for name, typ := range msg.Variables {
 				varsm[name] = templates.VarData{
 					Name:  name,
@@ -235,31 +514,193 @@ func GetTranslationData(fsys fs.FS, path, pkgName string, opts ...GenerateOption
 				return nil, fmt.Errorf("error validating template %q: %w", msg.Template, err)
 			}
 
+			varTypes := make(map[string]string, len(vars))
+			for _, v := range vars {
+				varTypes[v.Name] = v.Type
+			}
+			types, err := checker.CheckVariables(varTypes)
+			if err != nil {
+				return nil, fmt.Errorf("error validating variables of message %q: %w", msgID, err)
+			}
+			exprTypes := make(map[string]gotypes.Type, len(msg.Variables))
+			for name := range msg.Variables {
+				exprTypes[snaker.ForceLowerCamelIdentifier(name)] = types[name]
+			}
+
+			if err := checker.CheckTemplateFields(msg.Template, types); err != nil {
+				return nil, fmt.Errorf("error validating template %q: %w", msg.Template, err)
+			}
+			if err := templates.CheckLocaleFuncs(msg.Template, lang); err != nil {
+				return nil, fmt.Errorf("error validating template %q: %w", msg.Template, err)
+			}
+
+			if key, p, _ := load.PluralBlock(msg); p != nil {
+				if basic, ok := types[p.Variable].Underlying().(*gotypes.Basic); !ok || basic.Info()&gotypes.IsInteger == 0 {
+					return nil, fmt.Errorf("error validating %s of message %q: variable %s of type %s is not an integer", key, msgID, p.Variable, msg.Variables[p.Variable])
+				}
+			}
+
 			for _, tpl := range msg.CustomTemplates {
-				if err := validator.ValidateCustomExpression(tpl.Expression, exprVars); err != nil {
-					return nil, fmt.Errorf("error validating custom template expression %q: %w", tpl.Expression, err)
+				// Expressions of plural and ordinal forms are generated, comparing MessageData.PluralForm.
+				if tpl.Plural == nil {
+					if err := validator.ValidateCustomExpression(tpl.Expression, exprVars); err != nil {
+						return nil, fmt.Errorf("error validating custom template expression %q: %w", tpl.Expression, err)
+					}
+					if err := checker.CheckExpression(tpl.Expression, exprTypes); err != nil {
+						return nil, fmt.Errorf("error validating custom template expression %q: %w", tpl.Expression, err)
+					}
+				}
+				if err := checker.CheckTemplateFields(tpl.Template, types); err != nil {
+					return nil, fmt.Errorf("error validating template %q: %w", tpl.Template, err)
+				}
+				if err := templates.CheckLocaleFuncs(tpl.Template, lang); err != nil {
+					return nil, fmt.Errorf("error validating template %q: %w", tpl.Template, err)
 				}
 			}
 
 			args := ""
+			callArgs := ""
 			for _, v := range vars {
 				args += fmt.Sprintf("%s %s, ", v.Param, v.Type)
+				callArgs += v.Param + ", "
 			}
 			args = strings.TrimSuffix(args, ", ")
+			callArgs = strings.TrimSuffix(callArgs, ", ")
+
+			argsType := ""
+			var locals []templates.VarData
+			if optsMap.ArgsStructs {
+				argsType = methodName + "Args"
+				// Expressions refer to variables by parameter name, e.g. a variable Args as args.
+				argsName := "args"
+				for slices.ContainsFunc(vars, func(v templates.VarData) bool { return v.Param == argsName }) {
+					argsName += "_"
+				}
+				args = argsName + " " + argsType
+				callArgs = argsName
+				for i := range vars {
+					vars[i].Ref = argsName + "." + vars[i].Name
+				}
+				exprIdents := map[string]bool{}
+				for _, tpl := range msg.CustomTemplates {
+					for _, ident := range expressionIdents(tpl.Expression) {
+						exprIdents[ident] = true
+					}
+				}
+				for _, v := range vars {
+					if exprIdents[v.Param] {
+						locals = append(locals, v)
+					}
+				}
+			} else {
+				for i := range vars {
+					vars[i].Ref = vars[i].Param
+				}
+			}
+
+			ec := &exampleChecker{checker: checker, msg: msg, vars: vars, types: types, argsType: argsType, tag: language.Make(lang), allowUnverified: optsMap.UnverifiedExamples}
+			examples, err := ec.examples()
+			if err != nil {
+				return nil, fmt.Errorf("error validating examples of message %q in %s: %w", msgID, lang, err)
+			}
+
+			errorType, errorSentinel := "", ""
+			if msg.Error {
+				name := strings.TrimSuffix(methodName, "Error")
+				errorType, errorSentinel = name+"Error", "Err"+name
+			}
 
-			transData.Messages = append(transData.Messages, templates.MessageData{
+			msgData := templates.MessageData{
+				ID:              msgID,
 				CamelLang:       camelLang,
 				MethodName:      methodName,
 				Args:            args,
+				CallArgs:        callArgs,
+				ArgsType:        argsType,
+				ErrorType:       errorType,
+				ErrorSentinel:   errorSentinel,
+				Locals:          locals,
 				Vars:            vars,
 				Template:        msg.Template,
-				CustomTemplates: msg.CustomTemplates,
-			})
+				LazyTemplates:   optsMap.LazyTemplates,
+				Examples:        examples,
+				CustomTemplates: append([]templates.CustomTemplate{}, msg.CustomTemplates...),
+			}
+			// Plural forms are computed once per rendering, before selecting a custom template.
+			for i, ct := range msgData.CustomTemplates {
+				if ct.Plural == nil {
+					continue
+				}
+				if msgData.PluralForm == nil {
+					formVar := "form"
+					for slices.ContainsFunc(vars, func(v templates.VarData) bool { return v.Param == formVar }) {
+						formVar += "_"
+					}
+					rules := "Cardinal"
+					if ct.Plural.Ordinal {
+						rules = "Ordinal"
+					}
+					v := vars[slices.IndexFunc(vars, func(v templates.VarData) bool { return v.Name == ct.Plural.Variable })]
+					msgData.PluralForm = &templates.PluralFormData{Var: formVar, Rules: rules, Ref: v.Ref}
+				}
+				msgData.CustomTemplates[i].Expression = fmt.Sprintf("%s == plural.%s", msgData.PluralForm.Var, strings.ToUpper(ct.Plural.Form[:1])+ct.Plural.Form[1:])
+			}
+			if optsMap.CompiledTemplates {
+				ref := func(v templates.VarData) string { return v.Ref }
+				msgData.CompiledDft = compileTemplate(msg.Template, vars, ref)
+				for i, ct := range msgData.CustomTemplates {
+					msgData.CustomTemplates[i].Compiled = compileTemplate(ct.Template, vars, ref)
+				}
+			}
+
+			transData.Messages = append(transData.Messages, msgData)
 		}
 		data.Translations = append(data.Translations, transData)
 	}
 
 	data.Messages = data.Translations[0].Messages // all translations have the same messages
 
+	// Error types must not collide with each other or with the errors returned by Render.
+	errorTypes := map[string]string{"UnknownMessageError": "", "MissingArgumentError": "", "InvalidArgumentError": ""}
+	for _, msg := range data.Messages {
+		if msg.ErrorType == "" {
+			continue
+		}
+		if other, ok := errorTypes[msg.ErrorType]; ok {
+			if other == "" {
+				return nil, fmt.Errorf("error type %s of message %q collides with a generated type", msg.ErrorType, msg.ID)
+			}
+			return nil, fmt.Errorf("error type %s of message %q collides with message %q", msg.ErrorType, msg.ID, other)
+		}
+		errorTypes[msg.ErrorType] = msg.ID
+	}
+
+	relativeTime := make(map[string]bool)
+	for _, tr := range data.Translations {
+		for _, msg := range tr.Messages {
+			data.Funcs = data.Funcs || templates.UsesFuncs(msg.Template) ||
+				slices.ContainsFunc(msg.CustomTemplates, func(ct templates.CustomTemplate) bool { return templates.UsesFuncs(ct.Template) })
+			data.Plurals = data.Plurals || slices.ContainsFunc(msg.CustomTemplates, func(ct templates.CustomTemplate) bool { return ct.Plural != nil })
+			relativeTime[msg.ID] = relativeTime[msg.ID] || templates.UsesFunc(msg.Template, "relativeTime") ||
+				slices.ContainsFunc(msg.CustomTemplates, func(ct templates.CustomTemplate) bool { return templates.UsesFunc(ct.Template, "relativeTime") })
+		}
+	}
+	for i, msg := range data.Messages {
+		data.Messages[i].RelativeTime = relativeTime[msg.ID]
+	}
+	for i := range data.Translations {
+		data.Translations[i].Funcs = data.Funcs
+		data.Translations[i].Plurals = data.Plurals
+	}
+
+	data.BaseLang = data.Langs[0]
+	if optsMap.BaseLang != "" {
+		i := slices.IndexFunc(data.Langs, func(l templates.LangData) bool { return l.Lang == optsMap.BaseLang })
+		if i == -1 {
+			return nil, fmt.Errorf("base language %q has no translation file", optsMap.BaseLang)
+		}
+		data.BaseLang = data.Langs[i]
+	}
+
 	return &data, nil
 }
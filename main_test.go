@@ -1,21 +1,46 @@
 package i18ngo_test
 
 import (
+	"context"
 	"embed"
+	"encoding/json"
+	"fmt"
 	"go/format"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
 	"testing/fstest"
+	"time"
 
+	args_structs_t "github.com/danicc097/i18ngo/testdata/valid/args_structs/snapshots"
+	compiled_templates_t "github.com/danicc097/i18ngo/testdata/valid/compiled_templates/snapshots"
+	"github.com/danicc097/i18ngo/testdata/valid/custom_imports/models"
+	custom_imports_t "github.com/danicc097/i18ngo/testdata/valid/custom_imports/snapshots"
 	custom_template_t "github.com/danicc097/i18ngo/testdata/valid/custom_template/snapshots"
+	dev_translators_t "github.com/danicc097/i18ngo/testdata/valid/dev_translators/snapshots"
+	errors_t "github.com/danicc097/i18ngo/testdata/valid/errors/snapshots"
+	lazy_templates_t "github.com/danicc097/i18ngo/testdata/valid/lazy_templates/snapshots"
+	plurals_t "github.com/danicc097/i18ngo/testdata/valid/plurals/snapshots"
+	simple_variables_t "github.com/danicc097/i18ngo/testdata/valid/simple_variables/snapshots"
+	split_locales_t "github.com/danicc097/i18ngo/testdata/valid/split_locales/snapshots"
+	templ_components_t "github.com/danicc097/i18ngo/testdata/valid/templ_components/snapshots"
+	template_funcs_t "github.com/danicc097/i18ngo/testdata/valid/template_funcs/snapshots"
 
 	"github.com/danicc097/i18ngo"
+	"github.com/danicc097/i18ngo/i18ndev"
+	"github.com/danicc097/i18ngo/i18nrt"
+	"github.com/danicc097/i18ngo/templates"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
 )
 
 //go:embed testdata/valid/*
@@ -28,9 +53,52 @@ var testInvalidFS embed.FS
 
 const pkgName = "translations"
 
+// testGenerateOptions holds the options each testdata directory is generated with.
+var testGenerateOptions = map[string][]i18ngo.GenerateOption{
+	"custom_template":    {i18ngo.WithLangMiddleware(), i18ngo.WithRender(), i18ngo.WithMemoizedTranslators(), i18ngo.WithTestTranslators()},
+	"simple_variables":   {i18ngo.WithRender()},
+	"compiled_templates": {i18ngo.WithCompiledTemplates()},
+	"args_structs":       {i18ngo.WithArgsStructs(), i18ngo.WithCompiledTemplates(), i18ngo.WithMemoizedTranslators(), i18ngo.WithTestTranslators()},
+	"lazy_templates":     {i18ngo.WithLazyTemplates()},
+	"dev_translators":    {i18ngo.WithDevTranslators()},
+	"split_locales":      {i18ngo.WithLocaleBuildTags(), i18ngo.WithLazyTemplates(), i18ngo.WithLangMatcher()},
+	"templ_components":   {i18ngo.WithTemplComponents()},
+	"template_funcs":     {i18ngo.WithCompiledTemplates(), i18ngo.WithDevTranslators(), i18ngo.WithArgsStructs(), i18ngo.WithMemoizedTranslators()},
+	"plurals":            {i18ngo.WithCompiledTemplates(), i18ngo.WithDevTranslators()},
+	"examples":           {i18ngo.WithUnverifiedExamples()},
+}
+
+// testTypeScript holds the testdata directories also generated with TargetTypeScript, in i18n.ts.
+var testTypeScript = map[string]bool{
+	"compiled_templates": true,
+	"args_structs":       true,
+	"plurals":            true,
+}
+
+// testBackends holds the backends snapshots are generated with.
+var testBackends = map[string]templates.Backend{
+	"text_template": i18ngo.BackendTextTemplate,
+	"templ":         i18ngo.BackendTempl,
+}
+
+// testSplitLocales holds the testdata directories generated with GenerateFiles.
+var testSplitLocales = map[string]bool{
+	"split_locales": true,
+}
+
 func TestCodeGeneration(t *testing.T) {
 	t.Parallel()
 
+	for name, backend := range testBackends {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			testCodeGeneration(t, backend)
+		})
+	}
+}
+
+func testCodeGeneration(t *testing.T, backend templates.Backend) {
 	testdataDir := "testdata/valid"
 	entries, err := testValidFS.ReadDir(testdataDir)
 	if err != nil {
@@ -43,28 +111,54 @@ func TestCodeGeneration(t *testing.T) {
 		}
 
 		testName := filepath.Join(testdataDir, entry.Name())
-		data, err := i18ngo.GetTranslationData(testValidFS, testName, pkgName)
+		data, err := i18ngo.GetTranslationData(testValidFS, testName, pkgName, testGenerateOptions[entry.Name()]...)
 		require.NoError(t, err)
-		got, err := i18ngo.Generate(data)
+		var files map[string][]byte
+		if testSplitLocales[entry.Name()] {
+			files, err = i18ngo.GenerateFiles(data, i18ngo.WithBackend(backend))
+		} else {
+			var got []byte
+			got, err = i18ngo.Generate(data, i18ngo.WithBackend(backend))
+			files = map[string][]byte{"i18n.go": got}
+			if err == nil {
+				got, err = i18ngo.GenerateTests(data, i18ngo.WithBackend(backend))
+				if got != nil {
+					files["i18n_test.go"] = got
+				}
+			}
+			if err == nil && testTypeScript[entry.Name()] {
+				tsData := *data
+				tsData.Target = string(i18ngo.TargetTypeScript)
+				files["i18n.ts"], err = i18ngo.Generate(&tsData)
+			}
+		}
 		if err != nil {
 			t.Fatalf("Failed to generate Go code for %s/: %v", entry.Name(), err)
 		}
 
-		wantSnapshot := filepath.Join(testName, "snapshots", "i18n.go")
-		// format both src with gofmt:
-		want, err := os.ReadFile(wantSnapshot) // don't use fsys for tests, snapshot will be updated later
-		if err != nil {
-			t.Fatalf("Failed to read snapshot file for %s: %v", entry.Name(), err)
-		}
-		wantFmtted := mustFormat(t, want)
-		gotFmtted := mustFormat(t, got)
-		if diff := cmp.Diff(string(wantFmtted), string(gotFmtted)); diff != "" {
-			t.Errorf("Mismatch in %q (-want +got):\n%s", testdataDir+"/"+entry.Name(), diff)
-		}
+		snapshots, err := os.ReadDir(filepath.Join(testName, "snapshots"))
+		require.NoError(t, err)
+		require.Len(t, files, len(snapshots), "generated files of %s", entry.Name())
+
+		for name, got := range files {
+			wantSnapshot := filepath.Join(testName, "snapshots", name)
+			// format both src with gofmt:
+			want, err := os.ReadFile(wantSnapshot) // don't use fsys for tests, snapshot will be updated later
+			if err != nil {
+				t.Fatalf("Failed to read snapshot file for %s: %v", entry.Name(), err)
+			}
+			if strings.HasSuffix(name, ".go") {
+				want, got = mustFormat(t, want), mustFormat(t, got)
+			}
+			if diff := cmp.Diff(string(want), string(got)); diff != "" {
+				t.Errorf("Mismatch in %q (-want +got):\n%s", testdataDir+"/"+entry.Name()+"/"+name, diff)
+			}
 
-		if os.Getenv("SNAPSHOT_UPDATE") != "" {
-			if err := os.WriteFile(wantSnapshot, got, 0o666); err != nil {
-				t.Fatalf("Failed to update snapshot file for %s: %v", entry.Name(), err)
+			// template.go.tpl is the reference for other backends
+			if os.Getenv("SNAPSHOT_UPDATE") != "" && backend == i18ngo.BackendTextTemplate {
+				if err := os.WriteFile(wantSnapshot, got, 0o666); err != nil {
+					t.Fatalf("Failed to update snapshot file for %s: %v", entry.Name(), err)
+				}
 			}
 		}
 	}
@@ -114,19 +208,40 @@ func TestInvalidCodeGeneration(t *testing.T) {
 	}
 }
 
-func TestWithCustomTemplate(t *testing.T) {
-	t.Skip(`
-	TODO: allow to call custom templ generated code (provide a Generator interface).
-	This way users can use whatever templ version they want, or plain go code for all we care.
-	`)
+func TestUnverifiedExamples(t *testing.T) {
+	t.Parallel()
+
+	data, err := i18ngo.GetTranslationData(testValidFS, "testdata/valid/examples", pkgName, testGenerateOptions["examples"]...)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`example 0 of message "role_badge" in en: variable Role has type models.Role with methods`,
+		`example 0 of message "title" in en: custom template expression "strings.HasPrefix(name, \"Dr\")" doesn't evaluate to a constant`,
+	}, data.UnverifiedExamples())
+}
 
+// fixedBackend renders the same code for every file.
+type fixedBackend string
+
+func (b fixedBackend) Translations(w io.Writer, _ *templates.TemplateData) error {
+	_, err := io.WriteString(w, string(b))
+	return err
+}
+
+func (b fixedBackend) Locale(w io.Writer, _ templates.LocaleFileData) error {
+	_, err := io.WriteString(w, string(b))
+	return err
+}
+
+func (b fixedBackend) ExamplesTest(w io.Writer, _ *templates.TemplateData) error {
+	_, err := io.WriteString(w, string(b))
+	return err
+}
+
+func TestWithCustomTemplate(t *testing.T) {
 	t.Parallel()
 
 	testdataDir := "testdata"
 	fs := fstest.MapFS{
-		"templates/template.go.tpl": &fstest.MapFile{
-			Data: []byte("package customtemplate"),
-		},
 		"testdata/en.i18ngo.yaml": &fstest.MapFile{
 			Data: []byte(`messages:
   my_greeting:
@@ -137,12 +252,19 @@ func TestWithCustomTemplate(t *testing.T) {
 		},
 	}
 
-	data, err := i18ngo.GetTranslationData(fs, testdataDir, pkgName, i18ngo.WithFilesystemTemplate())
+	data, err := i18ngo.GetTranslationData(fs, testdataDir, pkgName)
 	require.NoError(t, err)
 
-	got, err := i18ngo.Generate(data)
+	got, err := i18ngo.Generate(data, i18ngo.WithBackend(fixedBackend("package customtemplate")))
 	require.NoError(t, err)
 	assert.Equal(t, "package customtemplate\n", string(got))
+
+	fs["templates/template.go.tpl"] = &fstest.MapFile{Data: []byte(`package {{ .PkgName }}
+
+const messages = {{ len .Messages }}`)}
+	got, err = i18ngo.Generate(data, i18ngo.WithFilesystemTemplate(fs))
+	require.NoError(t, err)
+	assert.Equal(t, "package translations\n\nconst messages = 1\n", string(got))
 }
 
 func TestTranslationsCustomTemplate(t *testing.T) {
@@ -215,3 +337,1017 @@ func TestTranslationsCustomTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestMemoizedTranslator(t *testing.T) {
+	t.Parallel()
+
+	cache := custom_template_t.NewMemoCache(custom_template_t.WithMemoSize(2))
+	tt := custom_template_t.NewMemoizedTranslators(custom_template_t.NewTranslators(), cache)
+
+	out, err := tt[custom_template_t.LangEn].MyGreeting(1, "Bob")
+	require.NoError(t, err)
+	require.Equal(t, "Hello Bob! You have 1 message.", out)
+
+	// same arguments in another language must not hit the english entry
+	out, err = tt[custom_template_t.LangEs].MyGreeting(1, "Bob")
+	require.NoError(t, err)
+	require.Equal(t, "Hola Bob! Tienes 1 mensaje.", out)
+
+	out, err = tt[custom_template_t.LangEn].MyGreeting(1, "Bob")
+	require.NoError(t, err)
+	require.Equal(t, "Hello Bob! You have 1 message.", out)
+	require.Equal(t, custom_template_t.MemoStats{Hits: 1, Misses: 2, Len: 2}, cache.Stats())
+
+	// evicts the least recently used entry, i.e. es
+	_, err = tt[custom_template_t.LangEn].MyGreeting(2, "Bob")
+	require.NoError(t, err)
+	_, err = tt[custom_template_t.LangEs].MyGreeting(1, "Bob")
+	require.NoError(t, err)
+	require.Equal(t, custom_template_t.MemoStats{Hits: 1, Misses: 4, Evictions: 2, Len: 2}, cache.Stats())
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache = custom_template_t.NewMemoCache(
+		custom_template_t.WithMemoTTL(time.Minute),
+		custom_template_t.WithMemoClock(func() time.Time { return now }),
+	)
+	tr := custom_template_t.NewMemoizedTranslatorWithCache(custom_template_t.LangEn, custom_template_t.NewTranslators()[custom_template_t.LangEn], cache)
+	_, err = tr.MyGreeting(1, "Bob")
+	require.NoError(t, err)
+	now = now.Add(time.Minute - time.Second)
+	_, err = tr.MyGreeting(1, "Bob")
+	require.NoError(t, err)
+	require.Equal(t, custom_template_t.MemoStats{Hits: 1, Misses: 1, Len: 1}, cache.Stats())
+	now = now.Add(time.Second + time.Nanosecond)
+	_, err = tr.MyGreeting(1, "Bob")
+	require.NoError(t, err)
+	require.Equal(t, custom_template_t.MemoStats{Hits: 1, Misses: 2, Len: 1}, cache.Stats())
+
+	require.PanicsWithValue(t, "memo cache size must be positive, got 0", func() { custom_template_t.WithMemoSize(0) })
+	require.PanicsWithValue(t, "memo cache size must be positive, got -1", func() { custom_template_t.WithMemoSize(-1) })
+}
+
+func TestTranslationsCompiledTemplates(t *testing.T) {
+	t.Parallel()
+
+	tt := custom_template_t.NewTranslators()
+	ct := compiled_templates_t.NewTranslators()
+
+	for _, lang := range []custom_template_t.Lang{custom_template_t.LangEn, custom_template_t.LangEs} {
+		for _, name := range []string{"Alice", `<script>alert("x'+y")</script>`, "a & b\x00"} {
+			for _, count := range []int{0, 1, 2, -3} {
+				t.Run(string(lang)+"_"+strconv.Itoa(count), func(t *testing.T) {
+					want, err := tt[lang].MyGreeting(count, name)
+					require.NoError(t, err)
+					got, err := ct[compiled_templates_t.Lang(lang)].MyGreeting(count, name)
+					require.NoError(t, err)
+					require.Equal(t, want, got)
+				})
+			}
+		}
+	}
+
+	en := ct[compiled_templates_t.LangEn]
+
+	out, err := en.InboxSummary(true, "Bob & Co", 3)
+	require.NoError(t, err)
+	require.Equal(t, "You have 3 unread items, Bob &amp; Co.", out)
+
+	out, err = en.InboxSummary(false, "Bob", 3)
+	require.NoError(t, err)
+	require.Equal(t, "You are all caught up, Bob.", out)
+
+	out, err = en.Progress("Upload", 1e21)
+	require.NoError(t, err)
+	require.Equal(t, "Upload is 1e&#43;21 done.", out)
+
+	out, err = en.Welcome("<i>Bob</i>")
+	require.NoError(t, err)
+	require.Equal(t, "Welcome, <b>&lt;i&gt;Bob&lt;/i&gt;</b>!", out)
+}
+
+func TestTranslationsArgsStructs(t *testing.T) {
+	t.Parallel()
+
+	tt := args_structs_t.NewTranslators()
+	en := tt[args_structs_t.LangEn]
+
+	out, err := en.MyGreeting(args_structs_t.MyGreetingArgs{Name: "Alice", Count: 1})
+	require.NoError(t, err)
+	require.Equal(t, "Hello Alice! You have 1 message.", out)
+
+	out, err = en.Welcome(args_structs_t.WelcomeArgs{Name: "<i>Bob</i>"})
+	require.NoError(t, err)
+	require.Equal(t, "Welcome, <b>&lt;i&gt;Bob&lt;/i&gt;</b>!", out)
+
+	// Args is both a variable and the name of the arguments parameter.
+	out, err = en.CommandUsage(args_structs_t.CommandUsageArgs{Command: "ls", Args: 0})
+	require.NoError(t, err)
+	require.Equal(t, "ls takes no arguments.", out)
+
+	out, err = args_structs_t.NewMemoizedTranslator(tt[args_structs_t.LangEs]).MyGreeting(args_structs_t.MyGreetingArgs{Name: "Ana"})
+	require.NoError(t, err)
+	require.Equal(t, "Hola Ana! No tienes ningún mensaje.", out)
+}
+
+func TestTranslationsLazyTemplates(t *testing.T) {
+	t.Parallel()
+
+	tt := custom_template_t.NewTranslators()
+	lt, err := lazy_templates_t.NewTranslatorsE()
+	require.NoError(t, err)
+	require.NoError(t, lazy_templates_t.Preload())
+
+	for _, lang := range []custom_template_t.Lang{custom_template_t.LangEn, custom_template_t.LangEs} {
+		for _, count := range []int{0, 1, 2} {
+			want, err := tt[lang].MyGreeting(count, "<b>Alice</b>")
+			require.NoError(t, err)
+			got, err := lt[lazy_templates_t.Lang(lang)].MyGreeting(count, "<b>Alice</b>")
+			require.NoError(t, err)
+			require.Equal(t, want, got)
+		}
+	}
+
+	out, err := lt[lazy_templates_t.LangEn].Welcome("<i>Bob</i>")
+	require.NoError(t, err)
+	require.Equal(t, "Welcome, <b>&lt;i&gt;Bob&lt;/i&gt;</b>!", out)
+}
+
+func TestTemplComponents(t *testing.T) {
+	t.Parallel()
+
+	ctx := templ_components_t.WithLang(context.Background(), templ_components_t.LangEs)
+
+	var buf strings.Builder
+	require.NoError(t, templ_components_t.MyGreetingC(1, "<b>Ana</b>").Render(ctx, &buf))
+	require.Equal(t, "Hola &lt;b&gt;Ana&lt;/b&gt;! Tienes 1 mensaje.", buf.String())
+
+	buf.Reset()
+	require.NoError(t, templ_components_t.NthItemC(1, []string{"a", "b"}).Render(context.Background(), &buf))
+	require.Equal(t, "Item: b", buf.String())
+
+	buf.Reset()
+	err := templ_components_t.NthItemC(2, []string{"a", "b"}).Render(ctx, &buf)
+	require.ErrorContains(t, err, "error calling index: reflect: slice index out of range")
+	require.Empty(t, buf.String())
+}
+
+func TestDevTranslators(t *testing.T) {
+	t.Parallel()
+
+	// translation files are edited below, so they're served from a copy
+	dir := t.TempDir()
+	require.NoError(t, os.CopyFS(dir, os.DirFS("testdata/valid/dev_translators")))
+	enFile := filepath.Join(dir, "en.i18ngo.yaml")
+	editEn := func(old, new string, modTime time.Time) {
+		content, err := os.ReadFile(enFile)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(enFile, []byte(strings.Replace(string(content), old, new, 1)), 0o644))
+		require.NoError(t, os.Chtimes(enFile, modTime, modTime))
+	}
+
+	tt := dev_translators_t.NewDevTranslators(os.DirFS(dir), i18ndev.WithCheckInterval(0))
+	throttled := dev_translators_t.NewDevTranslators(os.DirFS(dir), i18ndev.WithCheckInterval(time.Hour))
+
+	out, err := throttled[dev_translators_t.LangEn].MyGreeting(1, "Alice")
+	require.NoError(t, err)
+	require.Equal(t, "Hello Alice! You have 1 message.", out)
+
+	out, err = tt[dev_translators_t.LangEn].MyGreeting(1, "Alice")
+	require.NoError(t, err)
+	require.Equal(t, "Hello Alice! You have 1 message.", out)
+
+	out, err = tt[dev_translators_t.LangEs].MyGreeting(2, "<b>Ana</b>")
+	require.NoError(t, err)
+	require.Equal(t, "Hola &lt;b&gt;Ana&lt;/b&gt;! Tienes 2 mensajes.", out)
+
+	editEn("You have {{ .Count }} message.", "You have a single message.", time.Unix(1, 0))
+
+	out, err = tt[dev_translators_t.LangEn].MyGreeting(1, "Alice")
+	require.NoError(t, err)
+	require.Equal(t, "Hello Alice! You have a single message.", out)
+
+	// files aren't checked again until the interval passes
+	out, err = throttled[dev_translators_t.LangEn].MyGreeting(1, "Alice")
+	require.NoError(t, err)
+	require.Equal(t, "Hello Alice! You have 1 message.", out)
+
+	editEn(`expression: "count == 0"`, `expression: "count < 1"`, time.Unix(2, 0))
+
+	_, err = tt[dev_translators_t.LangEn].MyGreeting(0, "Alice")
+	require.ErrorContains(t, err, "regenerate translators")
+
+	editEn(`expression: "count < 1"`, `expression: "count == 0"`, time.Unix(3, 0))
+
+	out, err = tt[dev_translators_t.LangEn].MyGreeting(0, "Alice")
+	require.NoError(t, err)
+	require.Equal(t, "Hello Alice! You have no messages.", out)
+
+	editEn("{{ .Name }}", "{{ .Name ", time.Unix(4, 0))
+
+	_, err = tt[dev_translators_t.LangEn].MyGreeting(1, "Alice")
+	require.ErrorContains(t, err, "en: error parsing template")
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	t.Parallel()
+
+	for name, tt := range map[string]map[template_funcs_t.Lang]template_funcs_t.Translator{
+		"generated": template_funcs_t.NewTranslators(),
+		"dev":       template_funcs_t.NewDevTranslators(os.DirFS("testdata/valid/template_funcs")),
+	} {
+		t.Run(name, func(t *testing.T) {
+			out, err := tt[template_funcs_t.LangTr].CityBanner(template_funcs_t.CityBannerArgs{City: "diyarbakır"})
+			require.NoError(t, err)
+			require.Equal(t, "DİYARBAKIR'a hoş geldiniz!", out)
+
+			out, err = tt[template_funcs_t.LangTr].Stats(template_funcs_t.StatsArgs{Active: 0.5, Score: 1234.567, Users: 1000})
+			require.NoError(t, err)
+			require.Equal(t, "1.000 kullanıcı, %50 aktif, ortalama 1.234,57 puan.", out)
+
+			due := time.Date(2026, time.October, 18, 14, 5, 0, 0, time.UTC)
+			out, err = tt[template_funcs_t.LangEn].DueDate(template_funcs_t.DueDateArgs{Due: due})
+			require.NoError(t, err)
+			require.Equal(t, "Due on Oct 18, 2026 at 2:05 PM.", out)
+
+			out, err = tt[template_funcs_t.LangTr].DueDate(template_funcs_t.DueDateArgs{Due: due})
+			require.NoError(t, err)
+			require.Equal(t, "Son tarih 18 Eki 2026 14:05.", out)
+
+			out, err = tt[template_funcs_t.LangEn].Appointment(template_funcs_t.AppointmentArgs{At: due})
+			require.NoError(t, err)
+			require.Equal(t, "Your appointment: October 18, 2026 at 2:05:00 PM UTC.", out)
+
+			out, err = tt[template_funcs_t.LangEn].LastLogin(template_funcs_t.LastLoginArgs{At: time.Now().Add(-73 * time.Hour)})
+			require.NoError(t, err)
+			require.Equal(t, "Last login: 3 days ago.", out)
+
+			out, err = tt[template_funcs_t.LangTr].SessionExpiry(template_funcs_t.SessionExpiryArgs{Left: 90 * time.Second})
+			require.NoError(t, err)
+			require.Equal(t, "Oturumunuz 1 dakika sonra sona erecek.", out)
+
+			invoice := template_funcs_t.InvoiceTotalArgs{Amount: i18nrt.Money{Amount: 123456, Currency: "EUR"}, Tax: -0.75, Code: "EUR"}
+			out, err = tt[template_funcs_t.LangEn].InvoiceTotal(invoice)
+			require.NoError(t, err)
+			require.Equal(t, "Total due: €1,234.56, including -€0.75 VAT.", out)
+
+			invoice.Amount.Currency, invoice.Code = "JPY", "JPY"
+			out, err = tt[template_funcs_t.LangTr].InvoiceTotal(invoice)
+			require.NoError(t, err)
+			require.Equal(t, "Ödenecek tutar: ¥123.456, -¥1 KDV dahil.", out)
+
+			invoice.Amount.Currency = "XYZ"
+			_, err = tt[template_funcs_t.LangEn].InvoiceTotal(invoice)
+			require.ErrorContains(t, err, "currency: ")
+
+			out, err = tt[template_funcs_t.LangEn].TagList(template_funcs_t.TagListArgs{Tags: []string{"Go", "<b>i18n</b>"}})
+			require.NoError(t, err)
+			require.Equal(t, "Tags: go, &lt;b&gt;i18n&lt;/b&gt;.", out)
+
+			out, err = tt[template_funcs_t.LangEn].Summary(template_funcs_t.SummaryArgs{Text: strings.Repeat("e\u0301", 13)}) // combining acute accents
+			require.NoError(t, err)
+			require.Equal(t, strings.Repeat("e\u0301", 12)+"…", out)
+		})
+	}
+
+	// relative times change as time passes, so they aren't memoized
+	cache := template_funcs_t.NewMemoCache()
+	tr := template_funcs_t.NewMemoizedTranslators(template_funcs_t.NewTranslators(), cache)[template_funcs_t.LangEn]
+	args := template_funcs_t.LastLoginArgs{At: time.Now().Add(-73 * time.Hour)}
+	for range 2 {
+		out, err := tr.LastLogin(args)
+		require.NoError(t, err)
+		require.Equal(t, "Last login: 3 days ago.", out)
+	}
+	_, err := tr.Summary(template_funcs_t.SummaryArgs{Text: "Hi"})
+	require.NoError(t, err)
+	require.Equal(t, template_funcs_t.MemoStats{Misses: 1, Len: 1}, cache.Stats())
+}
+
+func TestPlurals(t *testing.T) {
+	t.Parallel()
+
+	for name, tt := range map[string]map[plurals_t.Lang]plurals_t.Translator{
+		"generated": plurals_t.NewTranslators(),
+		"dev":       plurals_t.NewDevTranslators(os.DirFS("testdata/valid/plurals")),
+	} {
+		t.Run(name, func(t *testing.T) {
+			for files, want := range map[uint]string{
+				1:   "1 plik został usunięty z /tmp.",
+				3:   "3 pliki zostały usunięte z /tmp.",
+				5:   "5 plików zostało usuniętych z /tmp.",
+				12:  "12 plików zostało usuniętych z /tmp.",
+				22:  "22 pliki zostały usunięte z /tmp.",
+				101: "101 plików zostało usuniętych z /tmp.",
+			} {
+				out, err := tt[plurals_t.LangPl].FilesDeleted(files, "/tmp")
+				require.NoError(t, err)
+				require.Equal(t, want, out)
+			}
+
+			out, err := tt[plurals_t.LangPl].FilesDeleted(math.MaxUint-11, "/tmp")
+			require.NoError(t, err)
+			require.Equal(t, fmt.Sprintf("%d pliki zostały usunięte z /tmp.", uint(math.MaxUint-11)), out)
+
+			out, err = tt[plurals_t.LangPl].UnreadMessages(math.MinInt)
+			require.NoError(t, err)
+			require.Equal(t, fmt.Sprintf("Masz %d nieprzeczytanych wiadomości.", math.MinInt), out)
+
+			out, err = tt[plurals_t.LangEn].UnreadMessages(-1)
+			require.NoError(t, err)
+			require.Equal(t, "You have -1 unread message.", out)
+
+			for rank, want := range map[int]string{
+				1:   "Ana finished 1st.",
+				2:   "Ana finished 2nd.",
+				3:   "Ana finished 3rd.",
+				4:   "Ana finished 4th.",
+				11:  "Ana finished 11th.",
+				12:  "Ana finished 12th.",
+				21:  "Ana finished 21st.",
+				112: "Ana finished 112th.",
+			} {
+				out, err := tt[plurals_t.LangEn].LeaderboardRank("Ana", rank)
+				require.NoError(t, err)
+				require.Equal(t, want, out)
+			}
+		})
+	}
+
+	data, err := i18ngo.GetTranslationData(testValidFS, "testdata/valid/plurals", pkgName)
+	require.NoError(t, err)
+	res, err := i18ngo.Export(data, i18ngo.ExportFormatJS)
+	require.NoError(t, err)
+	require.Empty(t, res.Unconverted)
+	var manifest map[string]string
+	require.NoError(t, json.Unmarshal(res.Files[i18ngo.ExportManifest], &manifest))
+	var bundle map[string]string
+	require.NoError(t, json.Unmarshal(res.Files[manifest["pl"]], &bundle))
+	require.Equal(t, "{count, plural, =0 {Nie masz nieprzeczytanych wiadomości.} one {Masz {count} nieprzeczytaną wiadomość.}"+
+		" few {Masz {count} nieprzeczytane wiadomości.} many {Masz {count} nieprzeczytanych wiadomości.}"+
+		" other {Masz {count} nieprzeczytanej wiadomości.}}", bundle["unread_messages"])
+
+	require.NoError(t, json.Unmarshal(res.Files[manifest["en"]], &bundle))
+	require.Equal(t, "{rank, selectordinal, one {{name} finished {rank}st.} two {{name} finished {rank}nd.}"+
+		" few {{name} finished {rank}rd.} other {{name} finished {rank}th.}}", bundle["leaderboard_rank"])
+}
+
+func TestSplitLocales(t *testing.T) {
+	t.Parallel()
+
+	// es is only compiled in with the i18n_es build tag.
+	_, esCompiled := split_locales_t.ParseLang("es")
+
+	tt, err := split_locales_t.NewTranslatorsE()
+	require.NoError(t, err)
+	_, ok := tt[split_locales_t.LangEs]
+	require.Equal(t, esCompiled, ok)
+
+	out, err := tt[split_locales_t.LangEn].MyGreeting(1, "Alice")
+	require.NoError(t, err)
+	require.Equal(t, "Hello Alice! You have 1 message.", out)
+
+	lang, _ := split_locales_t.MatchLang("es-ES")
+	if esCompiled {
+		require.Equal(t, split_locales_t.LangEs, lang)
+	} else {
+		require.Equal(t, split_locales_t.LangEn, lang)
+	}
+}
+
+func TestKeyTranslator(t *testing.T) {
+	t.Parallel()
+
+	var tr custom_template_t.Translator = custom_template_t.NewKeyTranslator()
+	out, err := tr.MyGreeting(3, "Bob")
+	require.NoError(t, err)
+	require.Equal(t, "my_greeting{count=3,name=Bob}", out)
+
+	out, err = args_structs_t.NewKeyTranslator().Welcome(args_structs_t.WelcomeArgs{Name: "Bob"})
+	require.NoError(t, err)
+	require.Equal(t, "welcome{name=Bob}", out)
+}
+
+func TestRecordingTranslator(t *testing.T) {
+	t.Parallel()
+
+	rec := custom_template_t.NewRecordingTranslator()
+	var tr custom_template_t.Translator = rec
+
+	out, err := tr.MyGreeting(3, "Bob")
+	require.NoError(t, err)
+	require.Equal(t, "my_greeting{count=3,name=Bob}", out)
+	_, err = tr.MyGreeting(0, "Ann")
+	require.NoError(t, err)
+
+	require.Equal(t, []custom_template_t.TranslatorCall{
+		{ID: custom_template_t.MessageIDMyGreeting, Args: map[string]any{"Count": 3, "Name": "Bob"}},
+		{ID: custom_template_t.MessageIDMyGreeting, Args: map[string]any{"Count": 0, "Name": "Ann"}},
+	}, rec.Calls())
+
+	rec.Reset()
+	require.Empty(t, rec.Calls())
+}
+
+func TestGenerateDocs(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"en.i18ngo.yaml": &fstest.MapFile{Data: []byte(`messages:
+  greeting:
+    template: "Hello {{ .Name }}!"
+    variables:
+      Name: string
+    custom_templates:
+      - expression: "name == \"\""
+        template: "Hello!"
+  farewell:
+    template: "Bye <b>{{ .Name }}</b>!"
+    variables:
+      Name: string
+`)},
+		"es.i18ngo.yaml": &fstest.MapFile{Data: []byte(`messages:
+  greeting:
+    template: "¡Hola {{ .Name }}!"
+    variables:
+      Name: string
+    custom_templates:
+      - expression: "name == \"\""
+        template: ""
+  farewell:
+    template: "Bye <b>{{ .Name }}</b>!"
+    variables:
+      Name: string
+`)},
+	}
+	data, err := i18ngo.GetTranslationData(fsys, ".", pkgName)
+	require.NoError(t, err)
+
+	md, err := i18ngo.GenerateDocs(data, i18ngo.DocsMarkdown)
+	require.NoError(t, err)
+	for _, want := range []string{
+		"| `es` | 1/2 (50%) | 1 | 1 |",
+		"Greeting(name string) (string, error)",
+		"| `Name` | `string` |",
+		"- When `name == \"\"`: _missing_",
+		"- Default: `Bye <b>{{ .Name }}</b>!`",
+	} {
+		require.Contains(t, string(md), want)
+	}
+
+	html, err := i18ngo.GenerateDocs(data, i18ngo.DocsHTML)
+	require.NoError(t, err)
+	require.Contains(t, string(html), `<td><code>es</code></td><td>1/2 (50%)</td><td>1</td><td>1</td>`)
+	require.Contains(t, string(html), `<code>Bye &lt;b&gt;{{ .Name }}&lt;/b&gt;!</code>`)
+	require.Contains(t, string(html), `<span class="missing">missing</span>`)
+
+	_, err = i18ngo.GenerateDocs(data, "pdf")
+	require.EqualError(t, err, `unknown docs format "pdf"`)
+}
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"en.i18ngo.yaml": &fstest.MapFile{Data: []byte(`messages:
+  greeting:
+    template: "Hello {{ .Name }}! You have {{ .Count }} messages."
+    variables:
+      Name: string
+      Count: int
+    custom_templates:
+      - expression: "count == 1"
+        template: "Hello {{ .Name }}! You have one message."
+      - expression: "count == 0"
+        template: "Hello {{ .Name }}! You have no messages."
+  role:
+    template: "{{ if .Admin }}<b>Admin</b>{{ else }}User's #{{ .ID }}{{ end }}"
+    variables:
+      Admin: bool
+      ID: int
+  pronoun:
+    template: "them"
+    variables:
+      Gender: string
+    custom_templates:
+      - expression: "gender == \"female\""
+        template: "her"
+      - expression: "gender == \"male\""
+        template: "him"
+  score:
+    template: "{{ printf \"%d%%\" .Score }}"
+    variables:
+      Score: int
+`)},
+	}
+	data, err := i18ngo.GetTranslationData(fsys, ".", pkgName)
+	require.NoError(t, err)
+
+	bundle := func(t *testing.T, res *i18ngo.ExportResult, lang string) map[string]string {
+		t.Helper()
+
+		var manifest map[string]string
+		require.NoError(t, json.Unmarshal(res.Files[i18ngo.ExportManifest], &manifest))
+		require.Regexp(t, `^`+lang+`\.[0-9a-f]{8}\.json$`, manifest[lang])
+		var b map[string]string
+		require.NoError(t, json.Unmarshal(res.Files[manifest[lang]], &b))
+
+		return b
+	}
+
+	res, err := i18ngo.Export(data, i18ngo.ExportI18next)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"greeting_one":   "Hello {{name}}! You have one message.",
+		"greeting_zero":  "Hello {{name}}! You have no messages.",
+		"greeting_other": "Hello {{name}}! You have {{count}} messages.",
+	}, bundle(t, res, "en"))
+	require.Len(t, res.Unconverted, 3)
+	require.EqualError(t, res.Unconverted[0], `message "pronoun" in en: custom templates compare gender, but i18next plurals require an integer count variable`)
+	require.EqualError(t, res.Unconverted[1], `message "role" in en: template "{{ if .Admin }}<b>Admin</b>{{ else }}User's #{{ .ID }}{{ end }}": unsupported condition .Admin: i18next has no select form`)
+	require.EqualError(t, res.Unconverted[2], `message "score" in en: template "{{ printf \"%d%%\" .Score }}": unsupported action {{printf "%d%%" .Score}}`)
+
+	res, err = i18ngo.Export(data, i18ngo.ExportFormatJS)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"greeting": "{count, plural, =1 {Hello {name}! You have one message.} =0 {Hello {name}! You have no messages.} other {Hello {name}! You have {count} messages.}}",
+		"pronoun":  "{gender, select, female {her} male {him} other {them}}",
+		"role":     "{admin, select, true {'<'b>Admin'<'/b>} other {User''s #{id}}}",
+	}, bundle(t, res, "en"))
+	require.Len(t, res.Unconverted, 1)
+
+	_, err = i18ngo.Export(data, "po")
+	require.EqualError(t, err, `unknown export format "po"`)
+}
+
+func TestTypeScriptExpressions(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"en.i18ngo.yaml": &fstest.MapFile{Data: []byte(`messages:
+  greeting:
+    template: "{{ printf \"%s has %d%%\" .Name .Count }}"
+    variables:
+      Name: string
+      Count: int
+      Ratio: float64
+    custom_templates:
+      - expression: "count/2 == 1 && len(name) > 3 || !(ratio != 0.5)"
+        template: "a"
+      - expression: "ratio/2 >= 1e3 && name + \"!\" == \"<b>\\\"\""
+        template: "b"
+`)},
+	}
+	data, err := i18ngo.GetTranslationData(fsys, ".", pkgName, i18ngo.WithTarget(i18ngo.TargetTypeScript))
+	require.NoError(t, err)
+	ts, err := i18ngo.Generate(data)
+	require.NoError(t, err)
+
+	require.Contains(t, string(ts), `if (((Math.trunc(count / 2) === 1) && (byteLength(name) > 3)) || !(ratio !== 0.5)) {`)
+	require.Contains(t, string(ts), `if (((ratio / 2) >= 1000) && ((name + "!") === "<b>\"")) {`)
+	require.Contains(t, string(ts), `s += escapeHTML(name + " has " + String(count) + "%");`)
+}
+
+func TestTypeScriptUnsupported(t *testing.T) {
+	t.Parallel()
+
+	data, err := i18ngo.GetTranslationData(testValidFS, "testdata/valid/custom_imports", pkgName, i18ngo.WithTarget(i18ngo.TargetTypeScript))
+	require.NoError(t, err)
+	_, err = i18ngo.Generate(data)
+	require.ErrorContains(t, err, `unsupported by the TypeScript target: message "last_login" in en: variable At has unsupported type time.Time`)
+	require.ErrorContains(t, err, `message "user_greeting" in en: variable User has unsupported type models.User`)
+}
+
+func TestUnknownTarget(t *testing.T) {
+	t.Parallel()
+
+	_, err := i18ngo.GetTranslationData(testValidFS, "testdata/valid/simple_variables", pkgName, i18ngo.WithTarget("typescript"))
+	require.EqualError(t, err, `unknown target "typescript", want go or ts`)
+
+	data, err := i18ngo.GetTranslationData(testValidFS, "testdata/valid/simple_variables", pkgName)
+	require.NoError(t, err)
+	data.Target = "js"
+	_, err = i18ngo.Generate(data)
+	require.EqualError(t, err, `unknown target "js", want go or ts`)
+}
+
+// tsParityCase is a message rendered by both the Go translators and the generated TypeScript module.
+type tsParityCase struct {
+	// ts is a TypeScript expression rendering the message with the t function of i18n.ts.
+	ts     string
+	render func() (string, error)
+}
+
+var tsParityCases = map[string][]tsParityCase{
+	"compiled_templates": {
+		{ts: `t("en").myGreeting(1, "<b>Bob</b>")`, render: func() (string, error) {
+			return compiled_templates_t.NewTranslators()[compiled_templates_t.LangEn].MyGreeting(1, "<b>Bob</b>")
+		}},
+		{ts: `t("es").myGreeting(3, "Ana & Co")`, render: func() (string, error) {
+			return compiled_templates_t.NewTranslators()[compiled_templates_t.LangEs].MyGreeting(3, "Ana & Co")
+		}},
+		{ts: `t("en").inboxSummary(true, "O'Brien", 3)`, render: func() (string, error) {
+			return compiled_templates_t.NewTranslators()[compiled_templates_t.LangEn].InboxSummary(true, "O'Brien", 3)
+		}},
+		{ts: `t("es").inboxSummary(false, "+Ana\u0000", 0)`, render: func() (string, error) {
+			return compiled_templates_t.NewTranslators()[compiled_templates_t.LangEs].InboxSummary(false, "+Ana\x00", 0)
+		}},
+		{ts: `t("en").progress("Bob", 0.000012)`, render: func() (string, error) {
+			return compiled_templates_t.NewTranslators()[compiled_templates_t.LangEn].Progress("Bob", 0.000012)
+		}},
+		{ts: `t("es").progress("Ana", 1234567.5)`, render: func() (string, error) {
+			return compiled_templates_t.NewTranslators()[compiled_templates_t.LangEs].Progress("Ana", 1234567.5)
+		}},
+		{ts: `t("fr").welcome("\"Ana\"")`, render: func() (string, error) {
+			return compiled_templates_t.NewTranslators()[compiled_templates_t.LangEn].Welcome(`"Ana"`)
+		}},
+	},
+	"args_structs": {
+		{ts: `t("en").commandUsage({ Command: "ls", Args: 0 })`, render: func() (string, error) {
+			return args_structs_t.NewTranslators()[args_structs_t.LangEn].CommandUsage(args_structs_t.CommandUsageArgs{Command: "ls"})
+		}},
+		{ts: `t("es").commandUsage({ Command: "cp", Args: 2 })`, render: func() (string, error) {
+			return args_structs_t.NewTranslators()[args_structs_t.LangEs].CommandUsage(args_structs_t.CommandUsageArgs{Command: "cp", Args: 2})
+		}},
+		{ts: `t("es").myGreeting({ Count: 1, Name: "Ana" })`, render: func() (string, error) {
+			return args_structs_t.NewTranslators()[args_structs_t.LangEs].MyGreeting(args_structs_t.MyGreetingArgs{Count: 1, Name: "Ana"})
+		}},
+		{ts: `t("en").progress({ Name: "Bob", Ratio: 0.5 })`, render: func() (string, error) {
+			return args_structs_t.NewTranslators()[args_structs_t.LangEn].Progress(args_structs_t.ProgressArgs{Name: "Bob", Ratio: 0.5})
+		}},
+	},
+	"plurals": {
+		{ts: `t("pl").filesDeleted(1, "/tmp")`, render: func() (string, error) {
+			return plurals_t.NewTranslators()[plurals_t.LangPl].FilesDeleted(1, "/tmp")
+		}},
+		{ts: `t("pl").filesDeleted(22, "/tmp")`, render: func() (string, error) {
+			return plurals_t.NewTranslators()[plurals_t.LangPl].FilesDeleted(22, "/tmp")
+		}},
+		{ts: `t("pl").filesDeleted(112, "/tmp")`, render: func() (string, error) {
+			return plurals_t.NewTranslators()[plurals_t.LangPl].FilesDeleted(112, "/tmp")
+		}},
+		{ts: `t("en").leaderboardRank("Ana", 23)`, render: func() (string, error) {
+			return plurals_t.NewTranslators()[plurals_t.LangEn].LeaderboardRank("Ana", 23)
+		}},
+		{ts: `t("en").leaderboardRank("Ana", 11)`, render: func() (string, error) {
+			return plurals_t.NewTranslators()[plurals_t.LangEn].LeaderboardRank("Ana", 11)
+		}},
+		{ts: `t("en").unreadMessages(-1)`, render: func() (string, error) {
+			return plurals_t.NewTranslators()[plurals_t.LangEn].UnreadMessages(-1)
+		}},
+	},
+}
+
+// TestTypeScriptParity runs the generated TypeScript modules with node, skipping if node
+// can't run TypeScript (its type stripping requires Node.js 22.6 or later).
+func TestTypeScriptParity(t *testing.T) {
+	t.Parallel()
+
+	// CI installs node, so the test must not silently pass there.
+	skip := t.Skipf
+	if os.Getenv("CI") != "" {
+		skip = t.Fatalf
+	}
+	node, err := exec.LookPath("node")
+	if err != nil {
+		skip("node not found")
+	}
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"type": "module"}`), 0o644))
+	probe := filepath.Join(dir, "probe.ts")
+	require.NoError(t, os.WriteFile(probe, []byte("const n: number = 1;\n"), 0o644))
+	if out, err := exec.Command(node, "--experimental-strip-types", probe).CombinedOutput(); err != nil {
+		skip("node can't run TypeScript: %s", out)
+	}
+
+	for name, cases := range tsParityCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			moduleDir := filepath.Join(dir, name)
+			require.NoError(t, os.Mkdir(moduleDir, 0o755))
+			src, err := os.ReadFile(filepath.Join("testdata/valid", name, "snapshots", "i18n.ts"))
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "i18n.ts"), src, 0o644))
+
+			var script strings.Builder
+			script.WriteString("import { t } from \"./i18n.ts\";\n\nconsole.log(JSON.stringify([\n")
+			want := make([]string, 0, len(cases))
+			for _, c := range cases {
+				fmt.Fprintf(&script, "  %s,\n", c.ts)
+				out, err := c.render()
+				require.NoError(t, err)
+				want = append(want, out)
+			}
+			script.WriteString("]));\n")
+			require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "check.ts"), []byte(script.String()), 0o644))
+
+			cmd := exec.Command(node, "--experimental-strip-types", "--no-warnings", "check.ts")
+			cmd.Dir = moduleDir
+			var stderr strings.Builder
+			cmd.Stderr = &stderr
+			out, err := cmd.Output()
+			require.NoError(t, err, stderr.String())
+			var got []string
+			require.NoError(t, json.Unmarshal(out, &got))
+			require.Equal(t, want, got)
+		})
+	}
+}
+
+func TestTranslationsCustomImports(t *testing.T) {
+	t.Parallel()
+
+	tt := custom_imports_t.NewTranslators()
+
+	out, err := tt[custom_imports_t.LangEn].UserGreeting(models.User{Name: "Alice", Gender: models.Female})
+	require.NoError(t, err)
+	require.Equal(t, "Welcome back, Ms. Alice!", out)
+
+	out, err = tt[custom_imports_t.LangEs].UserGreeting(models.User{Name: "Juan", Gender: models.Male})
+	require.NoError(t, err)
+	require.Equal(t, "¡Hola Juan!", out)
+
+	out, err = tt[custom_imports_t.LangEn].LastLogin(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, "Last login in 2024.", out)
+}
+
+func TestContextTranslator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	require.Equal(t, custom_template_t.LangEn, custom_template_t.LangFromContext(ctx))
+
+	out, err := custom_template_t.T(ctx).MyGreeting(1, "Bob")
+	require.NoError(t, err)
+	require.Equal(t, "Hello Bob! You have 1 message.", out)
+
+	ctx = custom_template_t.WithLang(ctx, custom_template_t.LangEs)
+	require.Equal(t, custom_template_t.LangEs, custom_template_t.LangFromContext(ctx))
+
+	out, err = custom_template_t.T(ctx).MyGreeting(1, "Juan")
+	require.NoError(t, err)
+	require.Equal(t, "Hola Juan! Tienes 1 mensaje.", out)
+
+	ctx = custom_template_t.WithLang(ctx, custom_template_t.Lang("fr"))
+	out, err = custom_template_t.T(ctx).MyGreeting(1, "Bob")
+	require.NoError(t, err)
+	require.Equal(t, "Hello Bob! You have 1 message.", out)
+
+	rec := custom_template_t.NewRecordingTranslator()
+	ctx = custom_template_t.WithTranslators(ctx, map[custom_template_t.Lang]custom_template_t.Translator{custom_template_t.LangEn: rec})
+	out, err = custom_template_t.T(ctx).MyGreeting(1, "Bob")
+	require.NoError(t, err)
+	require.Equal(t, "my_greeting{count=1,name=Bob}", out)
+	require.Len(t, rec.Calls(), 1)
+}
+
+// TestSetTranslators isn't parallel, since it replaces the translators of the whole package.
+func TestSetTranslators(t *testing.T) {
+	rec := custom_template_t.NewRecordingTranslator()
+	custom_template_t.SetTranslators(map[custom_template_t.Lang]custom_template_t.Translator{
+		custom_template_t.LangEn: rec,
+		custom_template_t.LangEs: custom_template_t.NewKeyTranslator(),
+	})
+	t.Cleanup(func() { custom_template_t.SetTranslators(nil) })
+
+	out, err := custom_template_t.T(context.Background()).MyGreeting(1, "Bob")
+	require.NoError(t, err)
+	require.Equal(t, "my_greeting{count=1,name=Bob}", out)
+
+	out, err = custom_template_t.Render(custom_template_t.LangEs, custom_template_t.MessageIDMyGreeting, map[string]any{"Count": 2, "Name": "Ana"})
+	require.NoError(t, err)
+	require.Equal(t, "my_greeting{count=2,name=Ana}", out)
+	require.Len(t, rec.Calls(), 1)
+
+	tt := errors_t.NewTranslators()
+	errors_t.SetTranslators(map[errors_t.Lang]errors_t.Translator{errors_t.LangEn: tt[errors_t.LangEs]})
+	t.Cleanup(func() { errors_t.SetTranslators(nil) })
+	require.EqualError(t, &errors_t.NotFoundError{Resource: "User", ID: 42}, "No se ha encontrado User 42.")
+
+	custom_template_t.SetTranslators(nil)
+	out, err = custom_template_t.T(context.Background()).MyGreeting(1, "Bob")
+	require.NoError(t, err)
+	require.Equal(t, "Hello Bob! You have 1 message.", out)
+}
+
+func TestMatchLang(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		acceptLanguage string
+		want           custom_template_t.Lang
+		wantConf       language.Confidence
+	}{
+		{"es-MX,es;q=0.9,en;q=0.8", custom_template_t.LangEs, language.Exact},
+		{"es-MX", custom_template_t.LangEs, language.High},
+		{"fr-FR,en-GB;q=0.5", custom_template_t.LangEn, language.High},
+		{"fr", custom_template_t.LangEn, language.No},
+		{"", custom_template_t.LangEn, language.No},
+		{"not a header;;", custom_template_t.LangEn, language.No},
+	}
+	for _, tc := range tests {
+		t.Run(tc.acceptLanguage, func(t *testing.T) {
+			lang, conf := custom_template_t.MatchLang(tc.acceptLanguage)
+			require.Equal(t, tc.want, lang)
+			require.Equal(t, tc.wantConf, conf)
+		})
+	}
+
+	lang, ok := custom_template_t.ParseLang("ES")
+	require.True(t, ok)
+	require.Equal(t, custom_template_t.LangEs, lang)
+
+	_, ok = custom_template_t.ParseLang("es-MX")
+	require.False(t, ok)
+
+	_, ok = custom_template_t.ParseLang("??")
+	require.False(t, ok)
+}
+
+func TestLangMiddleware(t *testing.T) {
+	t.Parallel()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		out, err := custom_template_t.T(r.Context()).MyGreeting(1, "Bob")
+		require.NoError(t, err)
+		fmt.Fprint(w, out)
+	})
+
+	tests := []struct {
+		name     string
+		sources  []custom_template_t.LangSource
+		url      string
+		cookie   string
+		header   string
+		wantLang string
+		wantVary []string
+		wantBody string
+	}{
+		{
+			name:     "query parameter",
+			url:      "/?lang=es",
+			cookie:   "en",
+			header:   "en",
+			wantLang: "es",
+			wantBody: "Hola Bob! Tienes 1 mensaje.",
+		},
+		{
+			name:     "cookie when query parameter is unavailable",
+			url:      "/?lang=fr",
+			cookie:   "es",
+			wantLang: "es",
+			wantVary: []string{"Cookie"},
+			wantBody: "Hola Bob! Tienes 1 mensaje.",
+		},
+		{
+			name:     "accept language",
+			url:      "/",
+			header:   "es-AR,en;q=0.5",
+			wantLang: "es",
+			wantVary: []string{"Cookie", "Accept-Language"},
+			wantBody: "Hola Bob! Tienes 1 mensaje.",
+		},
+		{
+			name:     "base language",
+			url:      "/",
+			header:   "fr",
+			wantLang: "en",
+			wantVary: []string{"Cookie", "Accept-Language"},
+			wantBody: "Hello Bob! You have 1 message.",
+		},
+		{
+			name:     "custom sources",
+			sources:  []custom_template_t.LangSource{custom_template_t.QueryLangSource("locale")},
+			url:      "/?lang=en&locale=es",
+			wantLang: "es",
+			wantBody: "Hola Bob! Tienes 1 mensaje.",
+		},
+		{
+			name: "custom header source",
+			sources: []custom_template_t.LangSource{{
+				Resolve: func(r *http.Request) (custom_template_t.Lang, bool) {
+					return custom_template_t.ParseLang(r.Header.Get("Accept-Language"))
+				},
+				Headers: []string{"Accept-Language"},
+			}},
+			url:      "/",
+			header:   "es",
+			wantLang: "es",
+			wantVary: []string{"Accept-Language"},
+			wantBody: "Hola Bob! Tienes 1 mensaje.",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			if tc.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "lang", Value: tc.cookie})
+			}
+			if tc.header != "" {
+				req.Header.Set("Accept-Language", tc.header)
+			}
+			rec := httptest.NewRecorder()
+
+			custom_template_t.LangMiddleware(tc.sources...)(handler).ServeHTTP(rec, req)
+
+			require.Equal(t, tc.wantLang, rec.Header().Get("Content-Language"))
+			require.Equal(t, tc.wantVary, rec.Header().Values("Vary"))
+			require.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	out, err := custom_template_t.Render(custom_template_t.LangEs, custom_template_t.MessageIDMyGreeting, map[string]any{"Count": 0, "Name": "Ana"})
+	require.NoError(t, err)
+	require.Equal(t, "Hola Ana! No tienes ningún mensaje.", out)
+
+	out, err = custom_template_t.Render("fr", custom_template_t.MessageID("my_greeting"), map[string]any{"Count": 2, "Name": "Bob"})
+	require.NoError(t, err)
+	require.Equal(t, "Hello Bob! You have 2 messages.", out)
+
+	out, err = simple_variables_t.Render(simple_variables_t.LangEn, simple_variables_t.MessageIDMyGreeting, map[string]any{"Age": nil, "Name": "Bob"})
+	require.NoError(t, err)
+	require.Equal(t, "Hello Bob! You are  years old.", out)
+
+	_, err = custom_template_t.Render(custom_template_t.LangEn, "error.not_found", nil)
+	var unknownErr *custom_template_t.UnknownMessageError
+	require.ErrorAs(t, err, &unknownErr)
+	require.EqualError(t, err, `unknown message "error.not_found"`)
+
+	_, err = custom_template_t.Render(custom_template_t.LangEn, custom_template_t.MessageIDMyGreeting, map[string]any{"Name": "Bob"})
+	var missingErr *custom_template_t.MissingArgumentError
+	require.ErrorAs(t, err, &missingErr)
+	require.Equal(t, "Count", missingErr.Arg)
+
+	_, err = custom_template_t.Render(custom_template_t.LangEn, custom_template_t.MessageIDMyGreeting, map[string]any{"Count": "1", "Name": "Bob"})
+	var invalidErr *custom_template_t.InvalidArgumentError
+	require.ErrorAs(t, err, &invalidErr)
+	require.EqualError(t, err, `message "my_greeting": argument Count must be int, got string`)
+}
+
+func TestLocalizedErrors(t *testing.T) {
+	t.Parallel()
+
+	var err error = &errors_t.NotFoundError{Resource: "User", ID: 42}
+	err = fmt.Errorf("loading profile: %w", err)
+
+	require.EqualError(t, err, "loading profile: User 42 was not found.")
+	require.ErrorIs(t, err, errors_t.ErrNotFound)
+	require.NotErrorIs(t, err, errors_t.ErrQuotaExceeded)
+
+	var notFound *errors_t.NotFoundError
+	require.ErrorAs(t, err, &notFound)
+	require.Equal(t, 42, notFound.ID)
+
+	tt := errors_t.NewTranslators()
+	require.Equal(t, "No se ha encontrado User 42.", notFound.Localize(tt[errors_t.LangEs]))
+
+	quotaErr := &errors_t.QuotaExceededError{Limit: 1}
+	require.ErrorIs(t, quotaErr, errors_t.ErrQuotaExceeded)
+	require.Equal(t, "You have used your only request.", quotaErr.Error())
+	require.Equal(t, "Has usado tu única petición.", quotaErr.Localize(tt[errors_t.LangEs]))
+
+	// Error returns plain text, while Localize renders values escaped for HTML.
+	nameErr := &errors_t.InvalidNameError{Name: "O'Brien & <Co>"}
+	require.EqualError(t, nameErr, "O'Brien & <Co> isn't a valid name.")
+	require.Equal(t, "O&#39;Brien &amp; &lt;Co&gt; no es un nombre válido.", nameErr.Localize(tt[errors_t.LangEs]))
+}
+
+func TestWithBaseLang(t *testing.T) {
+	t.Parallel()
+
+	testName := "testdata/valid/custom_template"
+
+	data, err := i18ngo.GetTranslationData(testValidFS, testName, pkgName, i18ngo.WithBaseLang("es"))
+	require.NoError(t, err)
+	require.Equal(t, "es", data.BaseLang.Lang)
+
+	_, err = i18ngo.GetTranslationData(testValidFS, testName, pkgName, i18ngo.WithBaseLang("fr"))
+	require.ErrorContains(t, err, `base language "fr" has no translation file`)
+}
+
+func BenchmarkTranslators(b *testing.B) {
+	b.Run("template", func(b *testing.B) {
+		tr := custom_template_t.NewTranslators()[custom_template_t.LangEn]
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := tr.MyGreeting(i%3, "Alice"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("compiled", func(b *testing.B) {
+		tr := compiled_templates_t.NewTranslators()[compiled_templates_t.LangEn]
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := tr.MyGreeting(i%3, "Alice"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
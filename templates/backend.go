@@ -0,0 +1,32 @@
+package templates
+
+import (
+	"context"
+	"io"
+)
+
+// Backend renders generated Go code, which the generator then formats.
+type Backend interface {
+	// Translations renders shared declarations, and translators for every language unless data.SplitLocales.
+	Translations(w io.Writer, data *TemplateData) error
+	// Locale renders the translators of a single language.
+	Locale(w io.Writer, data LocaleFileData) error
+	// ExamplesTest renders a table test of message examples.
+	ExamplesTest(w io.Writer, data *TemplateData) error
+}
+
+// TemplBackend renders Go code with the templ components of this package,
+// compiled Go equivalents of template.go.tpl.
+type TemplBackend struct{}
+
+func (TemplBackend) Translations(w io.Writer, data *TemplateData) error {
+	return TranslationCode(data).Render(context.Background(), w)
+}
+
+func (TemplBackend) Locale(w io.Writer, data LocaleFileData) error {
+	return LocaleCode(data).Render(context.Background(), w)
+}
+
+func (TemplBackend) ExamplesTest(w io.Writer, data *TemplateData) error {
+	return ExamplesTestCode(data).Render(context.Background(), w)
+}
@@ -0,0 +1,56 @@
+{{- /* gotype: github.com/danicc097/i18ngo.docsData */ -}}
+<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Translation catalog: {{ .PkgName }}</title>
+<style>
+body { font-family: sans-serif; max-width: 960px; margin: 2em auto; padding: 0 1em; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; vertical-align: top; }
+code, pre { background: #f4f4f4; }
+.missing { color: #b00; }
+</style>
+</head>
+<body>
+<h1>Translation catalog</h1>
+<p>Package <code>{{ .PkgName }}</code> has {{ len .Messages }} messages in {{ len .Locales }} locales. Base language: <code>{{ .BaseLang.Lang }}</code>.</p>
+
+<h2>Completeness</h2>
+<table>
+<tr><th>Locale</th><th>Translated</th><th>Missing</th><th>Same as <code>{{ .BaseLang.Lang }}</code></th></tr>
+{{- range .Locales }}
+<tr><td><code>{{ .Lang }}</code></td><td>{{ .Translated }}/{{ .Total }} ({{ .Percent }}%)</td><td>{{ .Missing }}</td><td>{{ .SameAsBase }}</td></tr>
+{{- end }}
+</table>
+
+<h2>Messages</h2>
+{{- range .Messages }}
+<section id="{{ .ID }}">
+<h3>{{ .ID }}</h3>
+<pre><code>{{ .MethodName }}({{ .Args }}) (string, error)</code></pre>
+{{- if .ErrorType }}
+<p>Error type: <code>{{ .ErrorType }}</code>, matching <code>{{ .ErrorSentinel }}</code>.</p>
+{{- end }}
+{{- if .Vars }}
+<table>
+<tr><th>Variable</th><th>Type</th></tr>
+{{- range .Vars }}
+<tr><td><code>{{ .Name }}</code></td><td><code>{{ .Type }}</code></td></tr>
+{{- end }}
+</table>
+{{- end }}
+<table>
+<tr><th>Locale</th><th>Condition</th><th>Template</th></tr>
+{{- range .Translations }}
+{{- $lang := .Lang }}
+<tr><td><code>{{ $lang }}</code></td><td>default</td><td>{{ if .Template }}<code>{{ .Template }}</code>{{ else }}<span class="missing">missing</span>{{ end }}</td></tr>
+{{- range .CustomTemplates }}
+<tr><td><code>{{ $lang }}</code></td><td>{{ if .Plural }}<code>{{ .Plural.Variable }}</code> is {{ if .Plural.Ordinal }}ordinal{{ else }}plural{{ end }} {{ .Plural.Form }}{{ else }}<code>{{ .Expression }}</code>{{ end }}</td><td>{{ if .Template }}<code>{{ .Template }}</code>{{ else }}<span class="missing">missing</span>{{ end }}</td></tr>
+{{- end }}
+{{- end }}
+</table>
+</section>
+{{- end }}
+</body>
+</html>
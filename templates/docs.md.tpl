@@ -0,0 +1,42 @@
+{{- /* gotype: github.com/danicc097/i18ngo.docsData */ -}}
+# Translation catalog
+
+Package `{{ .PkgName }}` has {{ len .Messages }} messages in {{ len .Locales }} locales. Base language: `{{ .BaseLang.Lang }}`.
+
+## Completeness
+
+| Locale | Translated | Missing | Same as `{{ .BaseLang.Lang }}` |
+| --- | --- | --- | --- |
+{{- range .Locales }}
+| `{{ .Lang }}` | {{ .Translated }}/{{ .Total }} ({{ .Percent }}%) | {{ .Missing }} | {{ .SameAsBase }} |
+{{- end }}
+
+## Messages
+{{ range .Messages }}
+### {{ .ID }}
+
+```go
+{{ .MethodName }}({{ .Args }}) (string, error)
+```
+{{- if .ErrorType }}
+
+Error type: `{{ .ErrorType }}`, matching `{{ .ErrorSentinel }}`.
+{{- end }}
+{{- if .Vars }}
+
+| Variable | Type |
+| --- | --- |
+{{- range .Vars }}
+| `{{ .Name }}` | `{{ .Type }}` |
+{{- end }}
+{{- end }}
+{{- range .Translations }}
+
+#### {{ .Lang }}
+
+- Default: {{ code .Template }}
+{{- range .CustomTemplates }}
+- When {{ if .Plural }}{{ code .Plural.Variable }} is {{ if .Plural.Ordinal }}ordinal{{ else }}plural{{ end }} {{ .Plural.Form }}{{ else }}{{ code .Expression }}{{ end }}: {{ code .Template }}
+{{- end }}
+{{- end }}
+{{ end -}}
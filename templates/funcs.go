@@ -0,0 +1,406 @@
+package templates
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// templateFuncs returns the functions available in message templates, formatting values by the rules of tag
+// and the patterns of lf.
+func templateFuncs(tag language.Tag, lf localeFormats) map[string]any {
+	return map[string]any{
+		// Casers and printers are stateful, so one is created per call.
+		"upper": func(v any) string {
+			return cases.Upper(tag).String(fmt.Sprint(v))
+		},
+		"lower": func(v any) string {
+			return cases.Lower(tag).String(fmt.Sprint(v))
+		},
+		"title": func(v any) string {
+			return cases.Title(tag).String(fmt.Sprint(v))
+		},
+		"number": func(v any) (string, error) {
+			return formatNumber(tag, "number", number.Decimal(v), v)
+		},
+		"percent": func(v any) (string, error) {
+			return formatNumber(tag, "percent", number.Percent(v), v)
+		},
+		"decimal": func(v any, scale int) (string, error) {
+			return formatNumber(tag, "decimal", number.Decimal(v, number.Scale(scale)), v)
+		},
+		"date": func(t time.Time, style ...string) (string, error) {
+			i, err := timeStyle("date", style)
+			if err != nil {
+				return "", err
+			}
+			if err := lf.check("date", tag); err != nil {
+				return "", err
+			}
+			return lf.format(t, lf.date[i]), nil
+		},
+		"time": func(t time.Time, style ...string) (string, error) {
+			i, err := timeStyle("time", style)
+			if err != nil {
+				return "", err
+			}
+			if err := lf.check("time", tag); err != nil {
+				return "", err
+			}
+			return lf.format(t, lf.time[i]), nil
+		},
+		"datetime": func(t time.Time, style ...string) (string, error) {
+			i, err := timeStyle("datetime", style)
+			if err != nil {
+				return "", err
+			}
+			if err := lf.check("datetime", tag); err != nil {
+				return "", err
+			}
+			return lf.format(t, strings.NewReplacer("{0}", lf.time[i], "{1}", lf.date[i]).Replace(lf.dateTime[i])), nil
+		},
+		"relativeTime": func(v any) (string, error) {
+			if err := lf.check("relativeTime", tag); err != nil {
+				return "", err
+			}
+			return lf.relativeTime(tag, v)
+		},
+		"currency": func(amount any, code ...any) (string, error) {
+			if err := lf.check("currency", tag); err != nil {
+				return "", err
+			}
+			return lf.formatCurrency(tag, amount, code...)
+		},
+		"join":     joinFunc,
+		"truncate": truncateFunc,
+		"default":  defaultFunc,
+	}
+}
+
+// formatNumber formats v with f by the rules of tag, if v is a number.
+func formatNumber(tag language.Tag, name string, f number.Formatter, v any) (string, error) {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return message.NewPrinter(tag).Sprint(f), nil
+	}
+	return "", fmt.Errorf("%s: %T is not a number", name, v)
+}
+
+// localeFormats holds the CLDR date, time and currency patterns and names of a locale.
+type localeFormats struct {
+	// date, time and dateTime hold short, medium and long patterns.
+	// dateTime patterns combine a time {0} and a date {1}.
+	date, time, dateTime [3]string
+	months, shortMonths  [12]string
+	dayPeriods           [2]string // AM and PM
+	now                  string
+	// relative holds past and future patterns of a count {0} of each unit, by plural form.
+	relative map[string][2]map[string]string
+	// currency places a currency symbol ¤ and an amount #.
+	currency string
+	// decimal separates the integer and fractional digits of numbers.
+	decimal string
+}
+
+// check returns an error if lf holds no patterns, as for languages without CLDR data in i18ngo.
+func (lf localeFormats) check(name string, tag language.Tag) error {
+	if lf.now == "" {
+		return fmt.Errorf("%s: no patterns for %s", name, tag)
+	}
+	return nil
+}
+
+// timeStyle returns the index of the pattern for a short, medium or long style, medium by default.
+func timeStyle(name string, style []string) (int, error) {
+	if len(style) == 0 {
+		return 1, nil
+	}
+	if i := slices.Index([]string{"short", "medium", "long"}, style[0]); i >= 0 && len(style) == 1 {
+		return i, nil
+	}
+	return 0, fmt.Errorf("%s: unknown style %q, want short, medium or long", name, strings.Join(style, " "))
+}
+
+// format formats t with a CLDR date and time pattern.
+func (lf localeFormats) format(t time.Time, pattern string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		if c == '\'' {
+			end := strings.IndexByte(pattern[i+1:], '\'')
+			if end < 0 {
+				end = len(pattern) - i - 1
+			}
+			if end == 0 {
+				b.WriteByte('\'')
+			}
+			b.WriteString(pattern[i+1 : i+1+end])
+			i += end + 2
+			continue
+		}
+		n := 1
+		for i+n < len(pattern) && pattern[i+n] == c {
+			n++
+		}
+		i += n
+		pad := func(v int) string {
+			if n == 2 {
+				return fmt.Sprintf("%02d", v)
+			}
+			return strconv.Itoa(v)
+		}
+		switch c {
+		case 'y':
+			if n == 2 {
+				b.WriteString(fmt.Sprintf("%02d", t.Year()%100))
+			} else {
+				b.WriteString(strconv.Itoa(t.Year()))
+			}
+		case 'M':
+			switch n {
+			case 1, 2:
+				b.WriteString(pad(int(t.Month())))
+			case 3:
+				b.WriteString(lf.shortMonths[t.Month()-1])
+			default:
+				b.WriteString(lf.months[t.Month()-1])
+			}
+		case 'd':
+			b.WriteString(pad(t.Day()))
+		case 'H':
+			b.WriteString(pad(t.Hour()))
+		case 'h':
+			b.WriteString(pad((t.Hour()+11)%12 + 1))
+		case 'm':
+			b.WriteString(pad(t.Minute()))
+		case 's':
+			b.WriteString(pad(t.Second()))
+		case 'a':
+			b.WriteString(lf.dayPeriods[t.Hour()/12])
+		case 'z':
+			b.WriteString(t.Format("MST"))
+		default:
+			b.WriteString(strings.Repeat(string(c), n))
+		}
+	}
+	return b.String()
+}
+
+// relativeUnits are the units relative times are expressed in, largest first.
+// Years and months are calendar units counted in months, the others fixed durations.
+var relativeUnits = []struct {
+	name   string
+	months int
+	d      time.Duration
+}{
+	{name: "year", months: 12},
+	{name: "month", months: 1},
+	{name: "week", d: 7 * 24 * time.Hour},
+	{name: "day", d: 24 * time.Hour},
+	{name: "hour", d: time.Hour},
+	{name: "minute", d: time.Minute},
+	{name: "second", d: time.Second},
+}
+
+// pluralForms are the CLDR names of plural forms.
+var pluralForms = [...]string{
+	plural.Other: "other",
+	plural.Zero:  "zero",
+	plural.One:   "one",
+	plural.Two:   "two",
+	plural.Few:   "few",
+	plural.Many:  "many",
+}
+
+// relativeTime formats a time.Time relative to now, or a time.Duration from now, in the largest whole unit.
+func (lf localeFormats) relativeTime(tag language.Tag, v any) (string, error) {
+	now := time.Now()
+	switch v := v.(type) {
+	case time.Time:
+		return lf.relativeTimeAt(tag, now, v), nil
+	case time.Duration:
+		return lf.relativeTimeAt(tag, now, now.Add(v)), nil
+	}
+	return "", fmt.Errorf("relativeTime: %T is not a time.Time or time.Duration", v)
+}
+
+// relativeTimeAt formats t relative to now in the largest whole unit.
+// Years and months are whole calendar months between both dates in the location of t,
+// e.g. from January 31 to February 28 is a month, but from January 28 to February 27 isn't.
+func (lf localeFormats) relativeTimeAt(tag language.Tag, now, t time.Time) string {
+	d := t.Sub(now)
+	future := 0
+	if d > 0 {
+		future = 1
+	}
+	months := calendarMonths(now.In(t.Location()), t)
+	for _, u := range relativeUnits {
+		var n int
+		if u.months > 0 {
+			n = months / u.months
+		} else {
+			n = int(d.Abs() / u.d)
+		}
+		if n == 0 {
+			continue
+		}
+		forms := lf.relative[u.name][future]
+		pattern, ok := forms[pluralForms[plural.Cardinal.MatchPlural(tag, n, 0, 0, 0, 0)]]
+		if !ok {
+			pattern = forms["other"]
+		}
+		return strings.Replace(pattern, "{0}", message.NewPrinter(tag).Sprint(number.Decimal(n)), 1)
+	}
+	return lf.now
+}
+
+// calendarMonths returns the number of whole calendar months between a and b, in either order.
+func calendarMonths(a, b time.Time) int {
+	if b.Before(a) {
+		a, b = b, a
+	}
+	months := (b.Year()-a.Year())*12 + int(b.Month()-a.Month())
+	if months > 0 && addMonths(a, months).After(b) {
+		months--
+	}
+	return months
+}
+
+// addMonths adds months to t, clamping the day to the last one of the resulting month.
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	day := min(t.Day(), first.AddDate(0, 1, -1).Day())
+	return first.AddDate(0, 0, day-1)
+}
+
+// money is an exact amount in the minor unit of its currency, such as i18nrt.Money.
+type money interface {
+	MinorUnits() int64
+	CurrencyCode() string
+}
+
+// formatCurrency formats an amount of a currency rounded to its ISO 4217 minor unit.
+// Amounts implementing money, such as i18nrt.Money, carry their currency, and other numbers
+// are in the currency of ISO 4217 code.
+func (lf localeFormats) formatCurrency(tag language.Tag, amount any, code ...any) (string, error) {
+	m, isMoney := amount.(money)
+	switch {
+	case isMoney && len(code) > 0:
+		return "", fmt.Errorf("currency: %T carries its currency", amount)
+	case isMoney:
+		code = []any{m.CurrencyCode()}
+	case len(code) != 1:
+		return "", fmt.Errorf("currency: want a currency code for %T", amount)
+	}
+	unit, err := currency.ParseISO(fmt.Sprint(code[0]))
+	if err != nil {
+		return "", fmt.Errorf("currency: %w", err)
+	}
+	scale, ok := minorUnits(unit)
+	if !ok {
+		return "", fmt.Errorf("currency: %s has no minor unit", unit)
+	}
+	var s string
+	if isMoney {
+		s = lf.formatMinorUnits(tag, m.MinorUnits(), scale)
+	} else if s, err = formatNumber(tag, "currency", number.Decimal(amount, number.Scale(scale)), amount); err != nil {
+		return "", err
+	}
+
+	sign := ""
+	if abs, ok := strings.CutPrefix(s, "-"); ok {
+		sign, s = "-", abs
+	}
+	symbol := message.NewPrinter(tag).Sprint(currency.Symbol(unit))
+	return sign + strings.NewReplacer("¤", symbol, "#", s).Replace(lf.currency), nil
+}
+
+// isoMinorUnits holds the ISO 4217 minor units of currencies with other than 2, by code,
+// -1 for those without minor units such as gold.
+var isoMinorUnits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+	"XAG": -1, "XAU": -1, "XBA": -1, "XBB": -1, "XBC": -1, "XBD": -1, "XDR": -1,
+	"XPD": -1, "XPT": -1, "XSU": -1, "XTS": -1, "XUA": -1, "XXX": -1,
+}
+
+// minorUnits returns the number of decimal digits of the ISO 4217 minor unit of unit,
+// if it has one.
+func minorUnits(unit currency.Unit) (int, bool) {
+	digits, ok := isoMinorUnits[unit.String()]
+	if !ok {
+		return 2, true
+	}
+	return digits, digits >= 0
+}
+
+// formatMinorUnits formats an amount in minor units with scale decimals by the rules of tag.
+// Integer and fractional parts are formatted separately, since float64 can't hold every int64 exactly.
+func (lf localeFormats) formatMinorUnits(tag language.Tag, minor int64, scale int) string {
+	sign, abs := "", uint64(minor)
+	if minor < 0 {
+		sign, abs = "-", -abs
+	}
+	pow := uint64(1)
+	for range scale {
+		pow *= 10
+	}
+	s := sign + message.NewPrinter(tag).Sprint(number.Decimal(abs/pow))
+	if scale > 0 {
+		s += lf.decimal + fmt.Sprintf("%0*d", scale, abs%pow)
+	}
+	return s
+}
+
+// joinFunc joins the elements of a slice or array with sep.
+func joinFunc(sep string, items any) (string, error) {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", fmt.Errorf("join: %T is not a slice", items)
+	}
+	elems := make([]string, v.Len())
+	for i := range elems {
+		elems[i] = fmt.Sprint(v.Index(i).Interface())
+	}
+	return strings.Join(elems, sep), nil
+}
+
+// truncateFunc shortens text to n user-perceived characters followed by an ellipsis,
+// never splitting Unicode extended grapheme clusters.
+func truncateFunc(n int, v any) string {
+	s := fmt.Sprint(v)
+	rest, state := s, -1
+	for ; n > 0 && rest != ""; n-- {
+		_, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+	}
+	if rest == "" {
+		return s
+	}
+	return s[:len(s)-len(rest)] + "…"
+}
+
+// defaultFunc returns v, or dft if v is empty.
+func defaultFunc(dft, v any) any {
+	if v == nil {
+		return dft
+	}
+	if rv := reflect.ValueOf(v); rv.IsZero() || (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map) && rv.Len() == 0 {
+		return dft
+	}
+	return v
+}
@@ -0,0 +1,140 @@
+package templates
+
+import (
+	_ "embed"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template/parse"
+
+	"golang.org/x/text/language"
+)
+
+// funcsSource holds the template functions, whose declarations are copied into generated code.
+//
+//go:embed funcs.go
+var funcsSource string
+
+// FuncNames are the names of the functions available in message templates.
+var FuncNames = slices.Sorted(maps.Keys(templateFuncs(language.Und, localeFormats{})))
+
+// Funcs returns the functions available in message templates for tag, as generated code does.
+func Funcs(tag language.Tag) map[string]any {
+	lf, _ := localeFormatsOf(tag)
+	return templateFuncs(tag, lf)
+}
+
+// funcsImports parses the import declarations of funcsSource.
+var funcsImports = sync.OnceValue(func() *ast.File {
+	f, err := parser.ParseFile(token.NewFileSet(), "funcs.go", funcsSource, parser.ImportsOnly)
+	if err != nil {
+		panic(err)
+	}
+
+	return f
+})
+
+// FuncsCode returns the declarations implementing template functions in generated code.
+var FuncsCode = sync.OnceValue(func() string {
+	f := funcsImports()
+	imports := f.Decls[len(f.Decls)-1]
+
+	return strings.TrimSpace(funcsSource[imports.End()-1:])
+})
+
+// FuncsImports returns the import paths FuncsCode depends on.
+var FuncsImports = sync.OnceValue(func() []string {
+	f := funcsImports()
+	paths := make([]string, 0, len(f.Imports))
+	for _, spec := range f.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			panic(err)
+		}
+		paths = append(paths, path)
+	}
+
+	return paths
+})
+
+// UsesFuncs reports whether tpl calls any template function.
+func UsesFuncs(tpl string) bool {
+	return len(usedFuncs(tpl)) > 0
+}
+
+// UsesFunc reports whether tpl calls the template function name.
+func UsesFunc(tpl, name string) bool {
+	return slices.Contains(usedFuncs(tpl), name)
+}
+
+// CheckLocaleFuncs returns an error if tpl calls a function formatting values with CLDR patterns
+// that lang has none of.
+func CheckLocaleFuncs(tpl, lang string) error {
+	if _, ok := localeFormatsOf(language.Make(lang)); ok {
+		return nil
+	}
+	for _, name := range usedFuncs(tpl) {
+		if slices.Contains(localeFuncs, name) {
+			return fmt.Errorf("%s has no patterns for %s, only for %s", name, lang, strings.Join(slices.Sorted(maps.Keys(cldrFormats)), ", "))
+		}
+	}
+
+	return nil
+}
+
+// usedFuncs returns the template functions tpl calls, sorted.
+func usedFuncs(tpl string) []string {
+	tree := parse.New("")
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse(tpl, "", "", map[string]*parse.Tree{}); err != nil {
+		return nil
+	}
+
+	used := make(map[string]bool)
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n != nil {
+				for _, c := range n.Nodes {
+					walk(c)
+				}
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n != nil {
+				for _, cmd := range n.Cmds {
+					for _, arg := range cmd.Args {
+						walk(arg)
+					}
+				}
+			}
+		case *parse.IdentifierNode:
+			if slices.Contains(FuncNames, n.Ident) {
+				used[n.Ident] = true
+			}
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		}
+	}
+	walk(tree.Root)
+
+	return slices.Sorted(maps.Keys(used))
+}
@@ -0,0 +1,183 @@
+package templates
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		n    int
+		s    string
+		want string
+	}{
+		{name: "shorter", n: 5, s: "abc", want: "abc"},
+		{name: "exact", n: 3, s: "abc", want: "abc"},
+		{name: "longer", n: 2, s: "abc", want: "ab…"},
+		{name: "zero", n: 0, s: "abc", want: "…"},
+		{name: "flags", n: 2, s: "🇪🇸🇫🇷🇩🇪", want: "🇪🇸🇫🇷…"},
+		{name: "odd regional indicators", n: 1, s: "🇪🇸🇫", want: "🇪🇸…"},
+		{name: "zwj sequences", n: 1, s: "👨‍👩‍👧‍👦👨‍👩‍👧", want: "👨‍👩‍👧‍👦…"},
+		{name: "skin tone modifiers", n: 2, s: "👋🏿👋🏻👋", want: "👋🏿👋🏻…"},
+		{name: "zwj sequence with skin tone", n: 1, s: "👩🏽‍💻👩🏽‍💻", want: "👩🏽‍💻…"},
+		{name: "crlf", n: 1, s: "\r\nb", want: "\r\n…"},
+		{name: "combining mark", n: 1, s: "e\u0301b", want: "e\u0301…"},
+		{name: "tag sequence flag", n: 1, s: "🏴\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007Fb", want: "🏴\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F…"},
+		{name: "hangul jamo", n: 1, s: "\u1100\u1161\u11A8\u1100", want: "\u1100\u1161\u11A8…"},
+		{name: "prepended concatenation mark", n: 1, s: "\u0600\u0661b", want: "\u0600\u0661…"},
+		{name: "zwj after non-emoji", n: 1, s: "a\u200db", want: "a\u200d…"},
+		{name: "keycap", n: 1, s: "1\ufe0f\u20e32", want: "1\ufe0f\u20e3…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, truncateFunc(tt.n, tt.s))
+		})
+	}
+}
+
+func TestFuncNames(t *testing.T) {
+	t.Parallel()
+
+	require.Contains(t, FuncNames, "truncate")
+	require.IsIncreasing(t, FuncNames)
+	require.Contains(t, FuncsImports(), "golang.org/x/text/language")
+}
+
+func TestLocaleFuncs(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, time.October, 18, 14, 5, 0, 0, time.UTC)
+	out, err := Funcs(language.MustParse("es-MX"))["date"].(func(time.Time, ...string) (string, error))(at, "long")
+	require.NoError(t, err)
+	require.Equal(t, "18 de octubre de 2026", out)
+
+	_, err = Funcs(language.Polish)["date"].(func(time.Time, ...string) (string, error))(at)
+	require.EqualError(t, err, "date: no patterns for pl")
+	_, err = Funcs(language.Polish)["relativeTime"].(func(any) (string, error))(time.Hour)
+	require.EqualError(t, err, "relativeTime: no patterns for pl")
+
+	require.NoError(t, CheckLocaleFuncs("{{ date .At }}", "es-MX"))
+	require.NoError(t, CheckLocaleFuncs("{{ date .At }}", "en-US"))
+	require.NoError(t, CheckLocaleFuncs("{{ upper .Name }}", "pl"))
+	require.EqualError(t, CheckLocaleFuncs("{{ .At | datetime }}", "pl"), "datetime has no patterns for pl, only for de, en, en-GB, es, es-MX, fr, it, pt, pt-PT, tr")
+	require.ErrorContains(t, CheckLocaleFuncs("{{ date .At }}", "en-AU"), "date has no patterns for en-AU")
+	require.ErrorContains(t, CheckLocaleFuncs("{{ date .At }}", "sr-Latn"), "date has no patterns for sr-Latn")
+}
+
+func TestRegionalDates(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, time.October, 8, 14, 5, 0, 0, time.UTC)
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{lang: "en", want: "10/8/26, 2:05 PM"},
+		{lang: "en-US", want: "10/8/26, 2:05 PM"},
+		{lang: "en-GB", want: "08/10/2026, 14:05"},
+		{lang: "pt", want: "08/10/2026 14:05"},
+		{lang: "pt-BR", want: "08/10/2026 14:05"},
+		{lang: "pt-PT", want: "08/10/26, 14:05"},
+		{lang: "es-MX", want: "08/10/26, 14:05"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			out, err := Funcs(language.MustParse(tt.lang))["datetime"].(func(time.Time, ...string) (string, error))(at, "short")
+			require.NoError(t, err)
+			require.Equal(t, tt.want, out)
+		})
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	t.Parallel()
+
+	lf, _ := localeFormatsOf(language.English)
+	tests := []struct {
+		name string
+		now  time.Time
+		t    time.Time
+		want string
+	}{
+		{name: "now", now: date(2026, 3, 1), t: date(2026, 3, 1), want: "now"},
+		{name: "days", now: date(2026, 3, 1), t: date(2026, 3, 4), want: "in 3 days"},
+		{name: "weeks", now: date(2026, 3, 1), t: date(2026, 3, 29), want: "in 4 weeks"},
+		{name: "short month", now: date(2026, 2, 1), t: date(2026, 3, 1), want: "in 1 month"},
+		{name: "month end", now: date(2026, 1, 31), t: date(2026, 2, 28), want: "in 1 month"},
+		{name: "before month end", now: date(2026, 1, 28), t: date(2026, 2, 27), want: "in 4 weeks"},
+		{name: "into a shorter month", now: date(2026, 1, 31), t: date(2026, 3, 3), want: "in 1 month"},
+		{name: "past months", now: date(2026, 5, 15), t: date(2026, 2, 16), want: "2 months ago"},
+		{name: "leap year", now: date(2024, 2, 29), t: date(2025, 2, 28), want: "in 1 year"},
+		{name: "almost a year", now: date(2026, 3, 2), t: date(2027, 3, 1), want: "in 11 months"},
+		{name: "past years", now: date(2026, 3, 1), t: date(2023, 3, 1), want: "3 years ago"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, lf.relativeTimeAt(language.English, tt.now, tt.t))
+		})
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 12, 0, 0, 0, time.UTC)
+}
+
+type testMoney struct {
+	amount int64
+	code   string
+}
+
+func (m testMoney) MinorUnits() int64    { return m.amount }
+func (m testMoney) CurrencyCode() string { return m.code }
+
+func TestCurrency(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		lang   string
+		amount any
+		code   []any
+		want   string
+	}{
+		{lang: "en", amount: testMoney{123456, "EUR"}, want: "€1,234.56"},
+		{lang: "es", amount: testMoney{-123456, "EUR"}, want: "-1.234,56\u00a0€"},
+		{lang: "de", amount: testMoney{math.MaxInt64, "EUR"}, want: "92.233.720.368.547.758,07\u00a0€"},
+		{lang: "en", amount: testMoney{math.MinInt64, "USD"}, want: "-$92,233,720,368,547,758.08"},
+		{lang: "fr", amount: testMoney{5, "JPY"}, want: "5\u00a0JPY"},
+		{lang: "en", amount: testMoney{5, "BHD"}, want: "BHD0.005"},
+		{lang: "tr", amount: 1234.5, code: []any{"TRY"}, want: "₺1.234,50"},
+		// ISO 4217 has 3 minor digits for IQD, CLDR displays none
+		{lang: "en", amount: testMoney{1500, "IQD"}, want: "IQD1.500"},
+		{lang: "en", amount: 1.5, code: []any{"IQD"}, want: "IQD1.500"},
+		{lang: "en-GB", amount: testMoney{123456, "GBP"}, want: "£1,234.56"},
+		{lang: "es-MX", amount: testMoney{123456, "MXN"}, want: "$1,234.56"},
+		{lang: "pt", amount: testMoney{123456, "BRL"}, want: "R$\u00a01.234,56"},
+		{lang: "pt-PT", amount: testMoney{123456, "EUR"}, want: "1\u00a0234,56\u00a0€"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang+"_"+fmt.Sprint(tt.amount), func(t *testing.T) {
+			out, err := Funcs(language.Make(tt.lang))["currency"].(func(any, ...any) (string, error))(tt.amount, tt.code...)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, out)
+		})
+	}
+
+	currencyFunc := Funcs(language.English)["currency"].(func(any, ...any) (string, error))
+	_, err := currencyFunc(testMoney{1, "EUR"}, "EUR")
+	require.EqualError(t, err, "currency: templates.testMoney carries its currency")
+	_, err = currencyFunc(1.5)
+	require.EqualError(t, err, "currency: want a currency code for float64")
+	_, err = currencyFunc(1.5, "XAU")
+	require.EqualError(t, err, "currency: XAU has no minor unit")
+
+	_, err = Funcs(language.Polish)["currency"].(func(any, ...any) (string, error))(testMoney{123456, "PLN"})
+	require.EqualError(t, err, "currency: no patterns for pl")
+}
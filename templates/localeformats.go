@@ -0,0 +1,280 @@
+package templates
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// cldrFormats holds the date, time and currency formats of supported locales, from CLDR, by language tag.
+// Formats of a base language are those of the region CLDR's data for it describes, e.g. en-US for en.
+var cldrFormats = map[string]localeFormats{
+	"de": {
+		date:        [3]string{"dd.MM.yy", "dd.MM.y", "d. MMMM y"},
+		time:        [3]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z"},
+		dateTime:    [3]string{"{1}, {0}", "{1}, {0}", "{1} 'um' {0}"},
+		months:      [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		shortMonths: [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		dayPeriods:  [2]string{"AM", "PM"},
+		now:         "jetzt",
+		relative: map[string][2]map[string]string{
+			"second": {{"one": "vor {0} Sekunde", "other": "vor {0} Sekunden"}, {"one": "in {0} Sekunde", "other": "in {0} Sekunden"}},
+			"minute": {{"one": "vor {0} Minute", "other": "vor {0} Minuten"}, {"one": "in {0} Minute", "other": "in {0} Minuten"}},
+			"hour":   {{"one": "vor {0} Stunde", "other": "vor {0} Stunden"}, {"one": "in {0} Stunde", "other": "in {0} Stunden"}},
+			"day":    {{"one": "vor {0} Tag", "other": "vor {0} Tagen"}, {"one": "in {0} Tag", "other": "in {0} Tagen"}},
+			"week":   {{"one": "vor {0} Woche", "other": "vor {0} Wochen"}, {"one": "in {0} Woche", "other": "in {0} Wochen"}},
+			"month":  {{"one": "vor {0} Monat", "other": "vor {0} Monaten"}, {"one": "in {0} Monat", "other": "in {0} Monaten"}},
+			"year":   {{"one": "vor {0} Jahr", "other": "vor {0} Jahren"}, {"one": "in {0} Jahr", "other": "in {0} Jahren"}},
+		},
+		currency: "#\u00a0¤",
+		decimal:  ",",
+	},
+	"en": {
+		date:        [3]string{"M/d/yy", "MMM d, y", "MMMM d, y"},
+		time:        [3]string{"h:mm a", "h:mm:ss a", "h:mm:ss a z"},
+		dateTime:    [3]string{"{1}, {0}", "{1}, {0}", "{1} 'at' {0}"},
+		months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		shortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		dayPeriods:  [2]string{"AM", "PM"},
+		now:         "now",
+		relative: map[string][2]map[string]string{
+			"second": {{"one": "{0} second ago", "other": "{0} seconds ago"}, {"one": "in {0} second", "other": "in {0} seconds"}},
+			"minute": {{"one": "{0} minute ago", "other": "{0} minutes ago"}, {"one": "in {0} minute", "other": "in {0} minutes"}},
+			"hour":   {{"one": "{0} hour ago", "other": "{0} hours ago"}, {"one": "in {0} hour", "other": "in {0} hours"}},
+			"day":    {{"one": "{0} day ago", "other": "{0} days ago"}, {"one": "in {0} day", "other": "in {0} days"}},
+			"week":   {{"one": "{0} week ago", "other": "{0} weeks ago"}, {"one": "in {0} week", "other": "in {0} weeks"}},
+			"month":  {{"one": "{0} month ago", "other": "{0} months ago"}, {"one": "in {0} month", "other": "in {0} months"}},
+			"year":   {{"one": "{0} year ago", "other": "{0} years ago"}, {"one": "in {0} year", "other": "in {0} years"}},
+		},
+		currency: "¤#",
+		decimal:  ".",
+	},
+	"en-GB": {
+		date:        [3]string{"dd/MM/y", "d MMM y", "d MMMM y"},
+		time:        [3]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z"},
+		dateTime:    [3]string{"{1}, {0}", "{1}, {0}", "{1} 'at' {0}"},
+		months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		shortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sept", "Oct", "Nov", "Dec"},
+		dayPeriods:  [2]string{"am", "pm"},
+		now:         "now",
+		relative: map[string][2]map[string]string{
+			"second": {{"one": "{0} second ago", "other": "{0} seconds ago"}, {"one": "in {0} second", "other": "in {0} seconds"}},
+			"minute": {{"one": "{0} minute ago", "other": "{0} minutes ago"}, {"one": "in {0} minute", "other": "in {0} minutes"}},
+			"hour":   {{"one": "{0} hour ago", "other": "{0} hours ago"}, {"one": "in {0} hour", "other": "in {0} hours"}},
+			"day":    {{"one": "{0} day ago", "other": "{0} days ago"}, {"one": "in {0} day", "other": "in {0} days"}},
+			"week":   {{"one": "{0} week ago", "other": "{0} weeks ago"}, {"one": "in {0} week", "other": "in {0} weeks"}},
+			"month":  {{"one": "{0} month ago", "other": "{0} months ago"}, {"one": "in {0} month", "other": "in {0} months"}},
+			"year":   {{"one": "{0} year ago", "other": "{0} years ago"}, {"one": "in {0} year", "other": "in {0} years"}},
+		},
+		currency: "¤#",
+		decimal:  ".",
+	},
+	"es": {
+		date:        [3]string{"d/M/yy", "d MMM y", "d 'de' MMMM 'de' y"},
+		time:        [3]string{"H:mm", "H:mm:ss", "H:mm:ss z"},
+		dateTime:    [3]string{"{1}, {0}", "{1}, {0}", "{1}, {0}"},
+		months:      [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		shortMonths: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		dayPeriods:  [2]string{"a. m.", "p. m."},
+		now:         "ahora",
+		relative: map[string][2]map[string]string{
+			"second": {{"one": "hace {0} segundo", "other": "hace {0} segundos"}, {"one": "dentro de {0} segundo", "other": "dentro de {0} segundos"}},
+			"minute": {{"one": "hace {0} minuto", "other": "hace {0} minutos"}, {"one": "dentro de {0} minuto", "other": "dentro de {0} minutos"}},
+			"hour":   {{"one": "hace {0} hora", "other": "hace {0} horas"}, {"one": "dentro de {0} hora", "other": "dentro de {0} horas"}},
+			"day":    {{"one": "hace {0} día", "other": "hace {0} días"}, {"one": "dentro de {0} día", "other": "dentro de {0} días"}},
+			"week":   {{"one": "hace {0} semana", "other": "hace {0} semanas"}, {"one": "dentro de {0} semana", "other": "dentro de {0} semanas"}},
+			"month":  {{"one": "hace {0} mes", "other": "hace {0} meses"}, {"one": "dentro de {0} mes", "other": "dentro de {0} meses"}},
+			"year":   {{"one": "hace {0} año", "other": "hace {0} años"}, {"one": "dentro de {0} año", "other": "dentro de {0} años"}},
+		},
+		currency: "#\u00a0¤",
+		decimal:  ",",
+	},
+	"es-MX": {
+		date:        [3]string{"dd/MM/yy", "d MMM y", "d 'de' MMMM 'de' y"},
+		time:        [3]string{"H:mm", "H:mm:ss", "H:mm:ss z"},
+		dateTime:    [3]string{"{1}, {0}", "{1}, {0}", "{1}, {0}"},
+		months:      [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		shortMonths: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		dayPeriods:  [2]string{"a.m.", "p.m."},
+		now:         "ahora",
+		relative: map[string][2]map[string]string{
+			"second": {{"one": "hace {0} segundo", "other": "hace {0} segundos"}, {"one": "dentro de {0} segundo", "other": "dentro de {0} segundos"}},
+			"minute": {{"one": "hace {0} minuto", "other": "hace {0} minutos"}, {"one": "dentro de {0} minuto", "other": "dentro de {0} minutos"}},
+			"hour":   {{"one": "hace {0} hora", "other": "hace {0} horas"}, {"one": "dentro de {0} hora", "other": "dentro de {0} horas"}},
+			"day":    {{"one": "hace {0} día", "other": "hace {0} días"}, {"one": "dentro de {0} día", "other": "dentro de {0} días"}},
+			"week":   {{"one": "hace {0} semana", "other": "hace {0} semanas"}, {"one": "dentro de {0} semana", "other": "dentro de {0} semanas"}},
+			"month":  {{"one": "hace {0} mes", "other": "hace {0} meses"}, {"one": "dentro de {0} mes", "other": "dentro de {0} meses"}},
+			"year":   {{"one": "hace {0} año", "other": "hace {0} años"}, {"one": "dentro de {0} año", "other": "dentro de {0} años"}},
+		},
+		currency: "¤#",
+		decimal:  ".",
+	},
+	"fr": {
+		date:        [3]string{"dd/MM/y", "d MMM y", "d MMMM y"},
+		time:        [3]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z"},
+		dateTime:    [3]string{"{1} {0}", "{1}, {0}", "{1} 'à' {0}"},
+		months:      [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		shortMonths: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		dayPeriods:  [2]string{"AM", "PM"},
+		now:         "maintenant",
+		relative: map[string][2]map[string]string{
+			"second": {{"one": "il y a {0} seconde", "other": "il y a {0} secondes"}, {"one": "dans {0} seconde", "other": "dans {0} secondes"}},
+			"minute": {{"one": "il y a {0} minute", "other": "il y a {0} minutes"}, {"one": "dans {0} minute", "other": "dans {0} minutes"}},
+			"hour":   {{"one": "il y a {0} heure", "other": "il y a {0} heures"}, {"one": "dans {0} heure", "other": "dans {0} heures"}},
+			"day":    {{"one": "il y a {0} jour", "other": "il y a {0} jours"}, {"one": "dans {0} jour", "other": "dans {0} jours"}},
+			"week":   {{"one": "il y a {0} semaine", "other": "il y a {0} semaines"}, {"one": "dans {0} semaine", "other": "dans {0} semaines"}},
+			"month":  {{"one": "il y a {0} mois", "other": "il y a {0} mois"}, {"one": "dans {0} mois", "other": "dans {0} mois"}},
+			"year":   {{"one": "il y a {0} an", "other": "il y a {0} ans"}, {"one": "dans {0} an", "other": "dans {0} ans"}},
+		},
+		currency: "#\u00a0¤",
+		decimal:  ",",
+	},
+	"it": {
+		date:        [3]string{"dd/MM/yy", "d MMM y", "d MMMM y"},
+		time:        [3]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z"},
+		dateTime:    [3]string{"{1}, {0}", "{1}, {0}", "{1} 'alle ore' {0}"},
+		months:      [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		shortMonths: [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		dayPeriods:  [2]string{"AM", "PM"},
+		now:         "ora",
+		relative: map[string][2]map[string]string{
+			"second": {{"one": "{0} secondo fa", "other": "{0} secondi fa"}, {"one": "tra {0} secondo", "other": "tra {0} secondi"}},
+			"minute": {{"one": "{0} minuto fa", "other": "{0} minuti fa"}, {"one": "tra {0} minuto", "other": "tra {0} minuti"}},
+			"hour":   {{"one": "{0} ora fa", "other": "{0} ore fa"}, {"one": "tra {0} ora", "other": "tra {0} ore"}},
+			"day":    {{"one": "{0} giorno fa", "other": "{0} giorni fa"}, {"one": "tra {0} giorno", "other": "tra {0} giorni"}},
+			"week":   {{"one": "{0} settimana fa", "other": "{0} settimane fa"}, {"one": "tra {0} settimana", "other": "tra {0} settimane"}},
+			"month":  {{"one": "{0} mese fa", "other": "{0} mesi fa"}, {"one": "tra {0} mese", "other": "tra {0} mesi"}},
+			"year":   {{"one": "{0} anno fa", "other": "{0} anni fa"}, {"one": "tra {0} anno", "other": "tra {0} anni"}},
+		},
+		currency: "#\u00a0¤",
+		decimal:  ",",
+	},
+	"pt": {
+		date:        [3]string{"dd/MM/y", "d 'de' MMM 'de' y", "d 'de' MMMM 'de' y"},
+		time:        [3]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z"},
+		dateTime:    [3]string{"{1} {0}", "{1} {0}", "{1} {0}"},
+		months:      [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		shortMonths: [12]string{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.", "jul.", "ago.", "set.", "out.", "nov.", "dez."},
+		dayPeriods:  [2]string{"AM", "PM"},
+		now:         "agora",
+		relative: map[string][2]map[string]string{
+			"second": {{"one": "há {0} segundo", "other": "há {0} segundos"}, {"one": "em {0} segundo", "other": "em {0} segundos"}},
+			"minute": {{"one": "há {0} minuto", "other": "há {0} minutos"}, {"one": "em {0} minuto", "other": "em {0} minutos"}},
+			"hour":   {{"one": "há {0} hora", "other": "há {0} horas"}, {"one": "em {0} hora", "other": "em {0} horas"}},
+			"day":    {{"one": "há {0} dia", "other": "há {0} dias"}, {"one": "em {0} dia", "other": "em {0} dias"}},
+			"week":   {{"one": "há {0} semana", "other": "há {0} semanas"}, {"one": "em {0} semana", "other": "em {0} semanas"}},
+			"month":  {{"one": "há {0} mês", "other": "há {0} meses"}, {"one": "em {0} mês", "other": "em {0} meses"}},
+			"year":   {{"one": "há {0} ano", "other": "há {0} anos"}, {"one": "em {0} ano", "other": "em {0} anos"}},
+		},
+		currency: "¤\u00a0#",
+		decimal:  ",",
+	},
+	"pt-PT": {
+		date:        [3]string{"dd/MM/yy", "dd/MM/y", "d 'de' MMMM 'de' y"},
+		time:        [3]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z"},
+		dateTime:    [3]string{"{1}, {0}", "{1}, {0}", "{1} 'às' {0}"},
+		months:      [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		shortMonths: [12]string{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.", "jul.", "ago.", "set.", "out.", "nov.", "dez."},
+		dayPeriods:  [2]string{"a.m.", "p.m."},
+		now:         "agora",
+		relative: map[string][2]map[string]string{
+			"second": {{"one": "há {0} segundo", "other": "há {0} segundos"}, {"one": "dentro de {0} segundo", "other": "dentro de {0} segundos"}},
+			"minute": {{"one": "há {0} minuto", "other": "há {0} minutos"}, {"one": "dentro de {0} minuto", "other": "dentro de {0} minutos"}},
+			"hour":   {{"one": "há {0} hora", "other": "há {0} horas"}, {"one": "dentro de {0} hora", "other": "dentro de {0} horas"}},
+			"day":    {{"one": "há {0} dia", "other": "há {0} dias"}, {"one": "dentro de {0} dia", "other": "dentro de {0} dias"}},
+			"week":   {{"one": "há {0} semana", "other": "há {0} semanas"}, {"one": "dentro de {0} semana", "other": "dentro de {0} semanas"}},
+			"month":  {{"one": "há {0} mês", "other": "há {0} meses"}, {"one": "dentro de {0} mês", "other": "dentro de {0} meses"}},
+			"year":   {{"one": "há {0} ano", "other": "há {0} anos"}, {"one": "dentro de {0} ano", "other": "dentro de {0} anos"}},
+		},
+		currency: "#\u00a0¤",
+		decimal:  ",",
+	},
+	"tr": {
+		date:        [3]string{"d.MM.y", "d MMM y", "d MMMM y"},
+		time:        [3]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z"},
+		dateTime:    [3]string{"{1} {0}", "{1} {0}", "{1} {0}"},
+		months:      [12]string{"Ocak", "Şubat", "Mart", "Nisan", "Mayıs", "Haziran", "Temmuz", "Ağustos", "Eylül", "Ekim", "Kasım", "Aralık"},
+		shortMonths: [12]string{"Oca", "Şub", "Mar", "Nis", "May", "Haz", "Tem", "Ağu", "Eyl", "Eki", "Kas", "Ara"},
+		dayPeriods:  [2]string{"ÖÖ", "ÖS"},
+		now:         "şimdi",
+		relative: map[string][2]map[string]string{
+			"second": {{"other": "{0} saniye önce"}, {"other": "{0} saniye sonra"}},
+			"minute": {{"other": "{0} dakika önce"}, {"other": "{0} dakika sonra"}},
+			"hour":   {{"other": "{0} saat önce"}, {"other": "{0} saat sonra"}},
+			"day":    {{"other": "{0} gün önce"}, {"other": "{0} gün sonra"}},
+			"week":   {{"other": "{0} hafta önce"}, {"other": "{0} hafta sonra"}},
+			"month":  {{"other": "{0} ay önce"}, {"other": "{0} ay sonra"}},
+			"year":   {{"other": "{0} yıl önce"}, {"other": "{0} yıl sonra"}},
+		},
+		currency: "¤#",
+		decimal:  ",",
+	},
+}
+
+// localeFuncs are the template functions formatting values with the patterns of cldrFormats.
+var localeFuncs = []string{"currency", "date", "datetime", "relativeTime", "time"}
+
+// localeFormatsOf returns the formats of tag, if supported: those of its language and region, or of its
+// base language if tag has no region and script, or the ones CLDR's data for the base language describes.
+// Other regions and scripts aren't supported rather than formatted with the patterns of another region.
+// Functions of localeFuncs fail with the zero formats of unsupported languages.
+func localeFormatsOf(tag language.Tag) (localeFormats, bool) {
+	base, script, region := tag.Raw()
+	if lf, ok := cldrFormats[base.String()+"-"+region.String()]; ok {
+		return lf, true
+	}
+	lf, ok := cldrFormats[base.String()]
+	if !ok {
+		return localeFormats{}, false
+	}
+	likely := language.Make(base.String())
+	if likelyRegion, _ := likely.Region(); region != (language.Region{}) && region != likelyRegion {
+		return localeFormats{}, false
+	}
+	if likelyScript, _ := likely.Script(); script != (language.Script{}) && script != likelyScript {
+		return localeFormats{}, false
+	}
+
+	return lf, true
+}
+
+// LocaleFormatsCode returns a Go expression of the formats of lang, for templateFuncs in generated code.
+func LocaleFormatsCode(lang string) string {
+	lf, _ := localeFormatsOf(language.Make(lang))
+
+	var b strings.Builder
+	b.WriteString("localeFormats{\n")
+	fmt.Fprintf(&b, "date: %#v,\n", lf.date)
+	fmt.Fprintf(&b, "time: %#v,\n", lf.time)
+	fmt.Fprintf(&b, "dateTime: %#v,\n", lf.dateTime)
+	fmt.Fprintf(&b, "months: %#v,\n", lf.months)
+	fmt.Fprintf(&b, "shortMonths: %#v,\n", lf.shortMonths)
+	fmt.Fprintf(&b, "dayPeriods: %#v,\n", lf.dayPeriods)
+	fmt.Fprintf(&b, "now: %q,\n", lf.now)
+	b.WriteString("relative: map[string][2]map[string]string{\n")
+	for _, u := range relativeUnits {
+		fmt.Fprintf(&b, "%q: {", u.name)
+		for i, forms := range lf.relative[u.name] {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString("{")
+			for j, form := range slices.Sorted(maps.Keys(forms)) {
+				if j > 0 {
+					b.WriteString(", ")
+				}
+				fmt.Fprintf(&b, "%q: %q", form, forms[form])
+			}
+			b.WriteString("}")
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("},\n")
+	fmt.Fprintf(&b, "currency: %q,\n", lf.currency)
+	fmt.Fprintf(&b, "decimal: %q,\n}", lf.decimal)
+
+	return b.String()
+}
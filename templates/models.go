@@ -1,10 +1,73 @@
 package templates
 
+import (
+	"fmt"
+	"strings"
+)
+
 type TemplateData struct {
-	PkgName      string
-	Langs        []LangData
-	Messages     []MessageData
-	Translations []TranslationData
+	PkgName string
+	// Imports are the custom import paths declared in translation files.
+	Imports []string
+	Langs   []LangData
+	// BaseLang is the fallback language when none is set.
+	BaseLang          LangData
+	Messages          []MessageData
+	Translations      []TranslationData
+	CompiledTemplates bool
+	ArgsStructs       bool
+	LazyTemplates     bool
+	DevTranslators    bool
+	LocaleBuildTags   bool
+	// Target is the language code is generated in, Go by default.
+	Target string
+	// SplitLocales generates shared declarations only, leaving translators to per-locale files.
+	SplitLocales bool
+	// TemplComponents generates a templ.Component per message.
+	TemplComponents bool
+	// Memoized generates MemoizedTranslator and MemoCache.
+	Memoized bool
+	// TestTranslators generates KeyTranslator and RecordingTranslator.
+	TestTranslators bool
+	// Render generates Render, looking messages up by MessageID.
+	Render bool
+	// LangMatcher generates Matcher, MatchLang and ParseLang.
+	LangMatcher bool
+	// LangMiddleware generates a net/http middleware storing the language of requests in their context.
+	LangMiddleware bool
+	// Funcs reports whether any template calls template functions, generated only if so.
+	Funcs bool
+	// Plurals reports whether any message has a plural block, generating pluralForm only if so.
+	Plurals bool
+}
+
+// UnverifiedExamples describes the examples that couldn't be rendered at generation time,
+// allowed by WithUnverifiedExamples and only verified by generated tests.
+func (d TemplateData) UnverifiedExamples() []string {
+	var unverified []string
+	for _, tr := range d.Translations {
+		for _, msg := range tr.Messages {
+			for i, ex := range msg.Examples {
+				if ex.Unverified != "" {
+					unverified = append(unverified, fmt.Sprintf("example %d of message %q in %s: %s", i, msg.ID, tr.Lang, ex.Unverified))
+				}
+			}
+		}
+	}
+
+	return unverified
+}
+
+// HasExamples reports whether any message has examples.
+func (d TemplateData) HasExamples() bool {
+	for _, tr := range d.Translations {
+		for _, msg := range tr.Messages {
+			if len(msg.Examples) > 0 {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 type LangData struct {
@@ -13,36 +76,213 @@ type LangData struct {
 }
 
 type MessageData struct {
-	CamelLang       string
-	MethodName      string
-	Args            string
+	// ID is the message key in translation files.
+	ID         string
+	CamelLang  string
+	MethodName string
+	Args       string
+	// CallArgs forwards the method arguments to another Translator.
+	CallArgs string
+	// ArgsType is the name of the generated arguments struct, if any.
+	ArgsType string
+	// ErrorType is the name of the generated error type, if the message is an error.
+	ErrorType string
+	// ErrorSentinel is the name of the sentinel error matching ErrorType.
+	ErrorSentinel string
+	// RelativeTime reports whether a template of the message calls relativeTime in any language,
+	// rendering text that changes as time passes.
+	RelativeTime bool
+	// Locals are variables declared from the arguments struct
+	// so that custom template expressions can reference them.
+	Locals          []VarData
 	Vars            []VarData
 	Template        string
 	CustomTemplates []CustomTemplate
+	// PluralForm is the plural form selecting custom templates of a plural block, if any.
+	PluralForm *PluralFormData
+	// CompiledDft is the Go code rendering Template, if it could be compiled.
+	CompiledDft *CompiledTemplate
+	// LazyTemplates parses templates on first use.
+	LazyTemplates bool
+	// Examples are renderings of the message verified by generated tests.
+	Examples []ExampleData
+}
+
+// PluralFormData declares the CLDR plural form of a variable once per rendering,
+// compared by the expressions of plural custom templates.
+type PluralFormData struct {
+	// Var is the name the form is declared as.
+	Var string
+	// Rules are the plural rules, Cardinal or Ordinal.
+	Rules string
+	// Ref is the Go expression referencing the variable.
+	Ref string
+}
+
+// ExampleData is a message example for generated tests.
+type ExampleData struct {
+	// Call holds the Go arguments of the message method.
+	Call string
+	Want string
+	// Unverified is why the example couldn't be rendered at generation time, if so,
+	// leaving it to generated tests.
+	Unverified string
+}
+
+// TemplateRef references a parsed template field of the message translator.
+type TemplateRef struct {
+	// Ref is the Go expression referencing the template.
+	Ref string
+	// Lazy reports whether Ref is a function parsing the template on first use.
+	Lazy bool
+}
+
+// TemplateRef returns a reference to the given template field from a translator method.
+func (m MessageData) TemplateRef(field string) TemplateRef {
+	if m.LazyTemplates {
+		return TemplateRef{Ref: "load" + m.CamelLang + "Templates()." + field, Lazy: true}
+	}
+	return TemplateRef{Ref: "t." + field}
+}
+
+// CallWith returns method arguments referencing each variable by name with the given prefix,
+// e.g. "e.Count, e.Name" for prefix "e.".
+func (m MessageData) CallWith(prefix string) string {
+	args := make([]string, 0, len(m.Vars))
+	for _, v := range m.Vars {
+		if m.ArgsType != "" {
+			args = append(args, v.Name+": "+prefix+v.Name)
+		} else {
+			args = append(args, prefix+v.Name)
+		}
+	}
+	if m.ArgsType != "" {
+		return m.ArgsType + "{" + strings.Join(args, ", ") + "}"
+	}
+	return strings.Join(args, ", ")
+}
+
+// IsCompiled reports whether any of the message templates was compiled to Go code.
+func (m MessageData) IsCompiled() bool {
+	if m.CompiledDft != nil {
+		return true
+	}
+	for _, ct := range m.CustomTemplates {
+		if ct.Compiled != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesTemplates reports whether any of the message templates is rendered through template execution.
+func (m MessageData) UsesTemplates() bool {
+	if m.CompiledDft == nil {
+		return true
+	}
+	for _, ct := range m.CustomTemplates {
+		if ct.Compiled == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// CompiledTemplate holds Go statements equivalent to executing a template.
+// Output is written to a strings.Builder named b.
+type CompiledTemplate struct {
+	Code string
 }
 
 type VarData struct {
 	Name  string
 	Type  string
 	Param string
+	// Ref is the Go expression referencing the variable inside generated methods.
+	Ref string
 }
 
 type TranslationData struct {
-	CamelLang string
-	Messages  []MessageData
+	CamelLang      string
+	Lang           string
+	Messages       []MessageData
+	LazyTemplates  bool
+	DevTranslators bool
+	// Funcs reports whether templates are parsed with template functions.
+	Funcs bool
+	// Plurals reports whether plural forms are selected, declaring the parsed language tag.
+	Plurals bool
+}
+
+// LocaleFileData is the data of a per-locale file.
+type LocaleFileData struct {
+	Root        *TemplateData
+	Translation TranslationData
+	// BuildTag is the build constraint of the file, if any.
+	BuildTag string
 }
 
 type CustomTemplate struct {
 	Expression string `yaml:"expression"`
 	Template   string `yaml:"template"`
+	// Compiled is the Go code rendering Template, if it could be compiled.
+	Compiled *CompiledTemplate `yaml:"-"`
+	// Plural is the plural form selecting Template, for templates of a plural block,
+	// compared in Go by an Expression set at generation.
+	Plural *PluralCase `yaml:"-"`
+}
+
+// Condition identifies the custom template among those of its message: its expression,
+// or its plural case for templates of a plural block, e.g. "Count is plural one".
+func (ct CustomTemplate) Condition() string {
+	if ct.Plural == nil {
+		return ct.Expression
+	}
+	kind := "plural"
+	if ct.Plural.Ordinal {
+		kind = "ordinal"
+	}
+
+	return fmt.Sprintf("%s is %s %s", ct.Plural.Variable, kind, ct.Plural.Form)
+}
+
+// PluralCase selects a template when the CLDR plural form of Variable is Form,
+// by ordinal rules if Ordinal.
+type PluralCase struct {
+	Variable string
+	Form     string
+	Ordinal  bool
 }
 
 type Message struct {
 	Template        string            `yaml:"template"`
 	Variables       map[string]string `yaml:"variables"`
 	CustomTemplates []CustomTemplate  `yaml:"custom_templates"`
+	// Error generates a localizable error type for the message.
+	Error bool `yaml:"error"`
+	// Examples are renderings of the message verified at generation time.
+	Examples []Example `yaml:"examples"`
+	// Plural holds templates by CLDR plural form, replacing Template.
+	Plural *Plural `yaml:"plural"`
+	// Ordinal holds templates by CLDR ordinal plural form, e.g. for 1st, 2nd and 3rd, replacing Template.
+	Ordinal *Plural `yaml:"ordinal"`
+}
+
+// Plural selects a template by the CLDR cardinal or ordinal plural form of an integer variable.
+type Plural struct {
+	Variable string `yaml:"variable"`
+	// Forms are templates by plural form: zero, one, two, few, many and other.
+	Forms map[string]string `yaml:",inline"`
+}
+
+// Example is a message rendering for the given variables.
+type Example struct {
+	Args map[string]any `yaml:"args"`
+	Want string         `yaml:"want"`
 }
 
 type Translations struct {
+	// Imports are Go import paths for packages used in variable types and expressions.
+	Imports  []string           `yaml:"imports"`
 	Messages map[string]Message `yaml:"messages"`
 }
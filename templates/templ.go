@@ -0,0 +1,1215 @@
+package templates
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/a-h/templ"
+	"github.com/kenshaw/snaker"
+)
+
+// The components below generate the same code as template.go.tpl, which is the reference for any change.
+
+// codeWriter writes generated code, keeping the first error.
+type codeWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (c *codeWriter) f(format string, args ...any) {
+	if c.err != nil {
+		return
+	}
+	_, c.err = fmt.Fprintf(c.w, format, args...)
+}
+
+func (c *codeWriter) s(s string) {
+	if c.err != nil {
+		return
+	}
+	_, c.err = io.WriteString(c.w, s)
+}
+
+func (c *codeWriter) render(ctx context.Context, component templ.Component) {
+	if c.err != nil {
+		return
+	}
+	c.err = component.Render(ctx, c.w)
+}
+
+func component(fn func(ctx context.Context, c *codeWriter)) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		c := &codeWriter{w: w}
+		fn(ctx, c)
+		return c.err
+	})
+}
+
+// TranslationCode renders shared declarations, and translators for every language unless data.SplitLocales.
+func TranslationCode(data *TemplateData) templ.Component {
+	return component(func(ctx context.Context, c *codeWriter) {
+		c.f("// Code generated by i18ngo. DO NOT EDIT.\npackage %s\n\n", data.PkgName)
+		c.render(ctx, importsCode(data))
+
+		c.s("\n// Translator is implemented by all language translators.\ntype Translator interface {\n")
+		for _, msg := range data.Messages {
+			c.f("%s(%s) (string, error)\n", msg.MethodName, msg.Args)
+		}
+		c.s("}\n")
+
+		if data.ArgsStructs {
+			for _, msg := range data.Messages {
+				c.f("\n// %s holds the arguments of %s.\ntype %[1]s struct {\n", msg.ArgsType, msg.MethodName)
+				for _, v := range msg.Vars {
+					c.f("%s %s\n", v.Name, v.Type)
+				}
+				c.s("}\n")
+			}
+		}
+
+		c.s("\n// MessageID identifies a message.\ntype MessageID string\n\nconst (\n")
+		for _, msg := range data.Messages {
+			c.f("MessageID%s MessageID = \"%s\"\n", msg.MethodName, msg.ID)
+		}
+		c.s(")\n\n// Lang represents available translated languages.\ntype Lang string\n\nconst (\n")
+		for _, lang := range data.Langs {
+			c.f("Lang%s Lang = \"%s\"\n", lang.CamelLang, lang.Lang)
+		}
+		c.s(")\n")
+
+		if data.Memoized {
+			c.s(memoCacheCode)
+			for _, msg := range data.Messages {
+				c.render(ctx, memoizedMethodCode(msg))
+			}
+		}
+
+		if data.TestTranslators {
+			c.s(keyTranslatorCode)
+			for _, msg := range data.Messages {
+				c.f("\n// %s renders the message id and arguments.\nfunc (KeyTranslator) %[1]s(%s) (string, error) {\n", msg.MethodName, msg.Args)
+				if len(msg.Vars) > 0 {
+					params := make([]string, 0, len(msg.Vars))
+					refs := make([]string, 0, len(msg.Vars))
+					for _, v := range msg.Vars {
+						params = append(params, v.Param+"=%v")
+						refs = append(refs, v.Ref)
+					}
+					c.f("return fmt.Sprintf(\"%s{%s}\", %s), nil\n", msg.ID, strings.Join(params, ","), strings.Join(refs, ", "))
+				} else {
+					c.f("return \"%s{}\", nil\n", msg.ID)
+				}
+				c.s("}\n")
+			}
+
+			c.s(recordingTranslatorCode)
+			for _, msg := range data.Messages {
+				c.f("\n// %s records the call and renders the message id and arguments.\nfunc (r *RecordingTranslator) %[1]s(%s) (string, error) {\n", msg.MethodName, msg.Args)
+				c.f("r.record(MessageID%s, map[string]any{\n", msg.MethodName)
+				for _, v := range msg.Vars {
+					c.f("\"%s\": %s,\n", v.Name, v.Ref)
+				}
+				c.f("})\nreturn KeyTranslator{}.%s(%s)\n}\n", msg.MethodName, msg.CallArgs)
+			}
+		}
+
+		if data.SplitLocales {
+			c.s("\n// locale holds the constructors of a language compiled into the binary.\ntype locale struct {\n")
+			if data.LangMatcher {
+				c.s("tag language.Tag\n")
+			}
+			c.s("new func() Translator\n")
+			if data.LazyTemplates {
+				c.s("preload func() error\n")
+			}
+			if data.DevTranslators {
+				c.s("newDev func(source *i18ndev.Source) Translator\n")
+			}
+			c.s("}\n")
+			c.s(registerLocaleCode)
+		} else {
+			c.s("\n// NewTranslators initializes all translators.\nfunc NewTranslators() map[Lang]Translator {\nreturn map[Lang]Translator{\n")
+			for _, lang := range data.Langs {
+				c.f("Lang%s: new%[1]s(),\n", lang.CamelLang)
+			}
+			c.s("}\n}\n")
+		}
+
+		c.f("\n// BaseLang is the fallback language when none is set.\nconst BaseLang = Lang%s\n", data.BaseLang.CamelLang)
+		c.s(langContextCode)
+
+		if data.LangMatcher {
+			if data.SplitLocales {
+				c.s(splitMatcherCode)
+			} else {
+				c.s("\nvar (\n// matcherLangs holds available languages in Matcher order, starting with BaseLang.\nmatcherLangs = []Lang{\nBaseLang,\n")
+				for _, lang := range data.Langs {
+					if lang.Lang != data.BaseLang.Lang {
+						c.f("Lang%s,\n", lang.CamelLang)
+					}
+				}
+				c.f("}\nmatcherTags = []language.Tag{\nlanguage.MustParse(\"%s\"),\n", data.BaseLang.Lang)
+				for _, lang := range data.Langs {
+					if lang.Lang != data.BaseLang.Lang {
+						c.f("language.MustParse(\"%s\"),\n", lang.Lang)
+					}
+				}
+				c.s("}\n)\n\n// Matcher matches language preferences against available languages, defaulting to BaseLang.\nvar Matcher = language.NewMatcher(matcherTags)\n")
+			}
+			c.s(matchLangCode)
+		}
+
+		c.s(translatorFromContextCode)
+
+		if data.Render {
+			c.s(renderTypesCode)
+			for _, msg := range data.Messages {
+				c.f("case MessageID%s:\n", msg.MethodName)
+				for _, v := range msg.Vars {
+					c.f("arg%s, err := renderArg[%s](id, args, \"%[1]s\")\nif err != nil {\nreturn \"\", err\n}\n", v.Name, v.Type)
+				}
+				c.f("return t.%s(%s)\n", msg.MethodName, msg.CallWith("arg"))
+			}
+			c.s(renderArgCode)
+		}
+
+		for _, msg := range data.Messages {
+			if msg.ErrorType == "" {
+				continue
+			}
+			c.f("\n// %s is matched by %s through errors.Is.\nvar %[1]s = errors.New(\"%[3]s\")\n", msg.ErrorSentinel, msg.ErrorType, msg.ID)
+			c.f("\n// %s is a localizable error for the %s message.\ntype %[1]s struct {\n", msg.ErrorType, msg.ID)
+			for _, v := range msg.Vars {
+				c.f("%s %s\n", v.Name, v.Type)
+			}
+			c.f(`}
+
+// Error renders the message in BaseLang with the translators set with SetTranslators as plain text,
+// unescaping the HTML-escaped values.
+func (e *%[1]s) Error() string {
+return html.UnescapeString(e.Localize(translators()[BaseLang]))
+}
+
+// Is reports whether target is %[2]s.
+func (e *%[1]s) Is(target error) bool {
+return target == %[2]s
+}
+
+// Localize renders the message with the given translator.
+// It falls back to the message id if rendering fails.
+func (e *%[1]s) Localize(t Translator) string {
+s, err := t.%[3]s(%[4]s)
+if err != nil {
+return string(MessageID%[3]s)
+}
+return s
+}
+`, msg.ErrorType, msg.ErrorSentinel, msg.MethodName, msg.CallWith("e."))
+		}
+
+		if data.LangMiddleware {
+			c.s(middlewareCode)
+		}
+
+		if data.TemplComponents {
+			for _, msg := range data.Messages {
+				c.f(`
+// %[1]sC renders %[1]s as HTML with the translator for the language carried by the context.
+// Rendering errors are returned by Render.
+func %[1]sC(%[2]s) templ.Component {
+return templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+s, err := T(ctx).%[1]s(%[3]s)
+if err != nil {
+return err
+}
+_, err = io.WriteString(w, s)
+return err
+})
+}
+`, msg.MethodName, msg.Args, msg.CallArgs)
+			}
+		}
+
+		if data.CompiledTemplates {
+			c.s(escapeHTMLCode)
+		}
+
+		if data.Funcs {
+			c.s("\n" + FuncsCode() + "\n")
+		}
+
+		if data.Plurals {
+			c.s(pluralFormFuncCode)
+		}
+
+		if data.LazyTemplates {
+			if data.Funcs {
+				c.s(lazyTemplateFuncsCode)
+			} else {
+				c.s(lazyTemplateCode)
+			}
+			c.s(preloadCode)
+			if data.SplitLocales {
+				c.s("for _, lang := range slices.Sorted(maps.Keys(locales)) {\nif err := locales[lang].preload(); err != nil {\nreturn fmt.Errorf(\"%s: %w\", lang, err)\n}\n}\n")
+			} else {
+				for _, lang := range data.Langs {
+					c.f("if err := load%sTemplates().preload(); err != nil {\nreturn fmt.Errorf(\"%s: %%w\", err)\n}\n", lang.CamelLang, lang.Lang)
+				}
+			}
+			c.s("return nil\n}\n")
+		}
+
+		if !data.SplitLocales {
+			for _, tr := range data.Translations {
+				c.render(ctx, translatorCode(tr))
+			}
+		}
+
+		if data.DevTranslators {
+			c.s(`
+// NewDevTranslators initializes translators reading translation files in fsys at runtime,
+// parsing templates again whenever the files change, for local development.
+// Only message text is live: regenerate after changing variables or custom template expressions.
+func NewDevTranslators(fsys fs.FS, opts ...i18ndev.Option) map[Lang]Translator {
+source := i18ndev.NewSource(fsys, ".", opts...)
+`)
+			if data.SplitLocales {
+				c.s("tt := make(map[Lang]Translator, len(locales))\nfor lang, l := range locales {\ntt[lang] = l.newDev(source)\n}\nreturn tt\n}\n")
+			} else {
+				c.s("return map[Lang]Translator{\n")
+				for _, lang := range data.Langs {
+					c.f("Lang%s: &dev%[1]s{source: source},\n", lang.CamelLang)
+				}
+				c.s("}\n}\n")
+				for _, tr := range data.Translations {
+					c.render(ctx, devTranslatorCode(tr))
+				}
+			}
+		}
+	})
+}
+
+// LocaleCode renders the translators of a single language.
+func LocaleCode(data LocaleFileData) templ.Component {
+	return component(func(ctx context.Context, c *codeWriter) {
+		if data.BuildTag != "" {
+			c.f("//go:build %s\n\n", data.BuildTag)
+		}
+		c.f("// Code generated by i18ngo. DO NOT EDIT.\npackage %s\n\n", data.Root.PkgName)
+		c.render(ctx, importsCode(data.Root))
+
+		tr := data.Translation
+		c.f("\nvar _ = registerLocale(Lang%s, locale{\n", tr.CamelLang)
+		if data.Root.LangMatcher {
+			c.f("tag: language.MustParse(\"%s\"),\n", tr.Lang)
+		}
+		c.f("new: func() Translator { return new%s() },\n", tr.CamelLang)
+		if tr.LazyTemplates {
+			c.f("preload: func() error { return load%sTemplates().preload() },\n", tr.CamelLang)
+		}
+		if tr.DevTranslators {
+			c.f("newDev: func(source *i18ndev.Source) Translator { return &dev%s{source: source} },\n", tr.CamelLang)
+		}
+		c.s("})\n")
+		c.render(ctx, translatorCode(tr))
+		if tr.DevTranslators {
+			c.render(ctx, devTranslatorCode(tr))
+		}
+	})
+}
+
+// ExamplesTestCode renders a table test of message examples.
+func ExamplesTestCode(data *TemplateData) templ.Component {
+	return component(func(ctx context.Context, c *codeWriter) {
+		c.f("// Code generated by i18ngo. DO NOT EDIT.\npackage %s\n\nimport (\n\"testing\"\n", data.PkgName)
+		if len(data.Imports) > 0 {
+			c.s("\n")
+			for _, imp := range data.Imports {
+				c.f("\"%s\"\n", imp)
+			}
+		}
+		c.s(`)
+
+func TestExamples(t *testing.T) {
+t.Parallel()
+
+tt := NewTranslators()
+tests := []struct {
+name   string
+lang   Lang
+render func(Translator) (string, error)
+want   string
+}{
+`)
+		for _, tr := range data.Translations {
+			for _, msg := range tr.Messages {
+				for i, ex := range msg.Examples {
+					c.f("{\nname: \"%s/%s/%d\",\nlang: Lang%s,\n", tr.Lang, msg.ID, i, tr.CamelLang)
+					c.f("render: func(tr Translator) (string, error) { return tr.%s(%s) },\nwant: %s,\n},\n", msg.MethodName, ex.Call, strconv.Quote(ex.Want))
+				}
+			}
+		}
+		c.s(examplesTestRunCode)
+	})
+}
+
+func importsCode(data *TemplateData) templ.Component {
+	return component(func(_ context.Context, c *codeWriter) {
+		c.s(`import (
+"container/list"
+"context"
+"errors"
+"fmt"
+"bytes"
+"html"
+"html/template"
+"io"
+"io/fs"
+"maps"
+"net/http"
+"reflect"
+"slices"
+"strconv"
+"strings"
+"sync"
+"sync/atomic"
+"time"
+`)
+		if data.Funcs {
+			for _, imp := range FuncsImports() {
+				c.f("\"%s\"\n", imp)
+			}
+		}
+		c.s(`
+"golang.org/x/text/feature/plural"
+"golang.org/x/text/language"
+
+"github.com/a-h/templ"
+"github.com/danicc097/i18ngo/i18ndev"
+`)
+		if len(data.Imports) > 0 {
+			c.s("\n")
+			for _, imp := range data.Imports {
+				c.f("\"%s\"\n", imp)
+			}
+		}
+		c.s(")\n")
+	})
+}
+
+func memoizedMethodCode(msg MessageData) templ.Component {
+	return component(func(_ context.Context, c *codeWriter) {
+		if msg.RelativeTime {
+			c.f(`
+// %[1]s computes the message without caching it, since its relative times change as time passes.
+func (m *MemoizedTranslator) %[1]s(%[2]s) (string, error) {
+return m.translator.%[1]s(%[3]s)
+}
+`, msg.MethodName, msg.Args, msg.CallArgs)
+			return
+		}
+
+		refs := make([]string, 0, len(msg.Vars))
+		for _, v := range msg.Vars {
+			refs = append(refs, ", "+v.Ref)
+		}
+		c.f(`
+// %[1]s checks the cache or computes the message if not already cached.
+func (m *MemoizedTranslator) %[1]s(%[2]s) (string, error) {
+cacheKey := fmt.Sprintf("%%s\x00%[1]s%[3]s", m.lang%[4]s)
+if rendered, ok := m.cache.get(cacheKey); ok {
+return rendered, nil
+}
+
+rendered, err := m.translator.%[1]s(%[5]s)
+if err != nil {
+return "", err
+}
+m.cache.add(cacheKey, rendered)
+return rendered, nil
+}
+`, msg.MethodName, msg.Args, strings.Repeat(`\x00%#v`, len(msg.Vars)), strings.Join(refs, ""), msg.CallArgs)
+	})
+}
+
+// templateFields calls fn with the field name and template of each template of msg that is not compiled.
+func templateFields(msg MessageData, fn func(field, tpl string)) {
+	if msg.CompiledDft == nil {
+		fn(msg.MethodName+"Dft", msg.Template)
+	}
+	for i, ct := range msg.CustomTemplates {
+		if ct.Compiled == nil {
+			fn(fmt.Sprintf("%sCustom%d", msg.MethodName, i), ct.Template)
+		}
+	}
+}
+
+func translatorCode(tr TranslationData) templ.Component {
+	return component(func(ctx context.Context, c *codeWriter) {
+		lang := snaker.ForceLowerCamelIdentifier(tr.CamelLang)
+		// funcs are the arguments passing template functions to lazyTemplate, or the call adding them to a template.
+		var lazyFuncs, funcs string
+		if tr.Funcs {
+			c.f("\n// %sFuncs holds template functions formatting values by %s rules.\nvar %[1]sFuncs = templateFuncs(language.MustParse(\"%[2]s\"), %[3]s)\n", lang, tr.Lang, LocaleFormatsCode(tr.Lang))
+			lazyFuncs, funcs = fmt.Sprintf(", %sFuncs", lang), fmt.Sprintf(".Funcs(%sFuncs)", lang)
+		}
+		if tr.Plurals {
+			c.f("\n// %sTag selects plural forms by %s rules.\nvar %[1]sTag = language.MustParse(\"%[2]s\")\n", lang, tr.Lang)
+		}
+		if tr.LazyTemplates {
+			c.f("\ntype %s struct{}\n\nfunc new%s() *%[1]s {\nreturn &%[1]s{}\n}\n", lang, tr.CamelLang)
+			c.f("\n// %sTemplates holds %s templates, each parsed on first use.\ntype %[1]sTemplates struct {\n", lang, tr.Lang)
+			for _, msg := range tr.Messages {
+				templateFields(msg, func(field, _ string) {
+					c.f("%s func() (*template.Template, error)\n", field)
+				})
+			}
+			c.f("}\n\n// load%sTemplates initializes %s templates on first use of the language.\n", tr.CamelLang, tr.Lang)
+			c.f("var load%sTemplates = sync.OnceValue(func() *%sTemplates {\nreturn &%[2]sTemplates{\n", tr.CamelLang, lang)
+			for _, msg := range tr.Messages {
+				templateFields(msg, func(field, tpl string) {
+					c.f("%s: lazyTemplate(\"%s\", %s%s),\n", field, strings.TrimSuffix(field, "Dft"), strconv.Quote(tpl), lazyFuncs)
+				})
+			}
+			c.f("}\n})\n\nfunc (t *%sTemplates) preload() error {\nfor _, load := range []func() (*template.Template, error){\n", lang)
+			for _, msg := range tr.Messages {
+				templateFields(msg, func(field, _ string) {
+					c.f("t.%s,\n", field)
+				})
+			}
+			c.s("} {\nif _, err := load(); err != nil {\nreturn err\n}\n}\nreturn nil\n}\n")
+		} else {
+			c.f("\ntype %s struct {\n", lang)
+			for _, msg := range tr.Messages {
+				templateFields(msg, func(field, _ string) {
+					c.f("%s *template.Template\n", field)
+				})
+			}
+			c.f("}\n\nfunc new%s() *%s {\nreturn &%[2]s{\n", tr.CamelLang, lang)
+			for _, msg := range tr.Messages {
+				templateFields(msg, func(field, tpl string) {
+					c.f("%s: template.Must(template.New(\"%s\")%s.Parse(%s)),\n", field, strings.TrimSuffix(field, "Dft"), funcs, strconv.Quote(tpl))
+				})
+			}
+			c.s("}\n}\n")
+		}
+
+		for _, msg := range tr.Messages {
+			c.render(ctx, translatorMethodCode(lang, msg))
+		}
+	})
+}
+
+func translatorMethodCode(lang string, msg MessageData) templ.Component {
+	return component(func(ctx context.Context, c *codeWriter) {
+		c.f("\n// %s renders a properly translated message.\nfunc (t *%s) %[1]s(%[3]s) (string, error) {\n", msg.MethodName, lang, msg.Args)
+		for _, v := range msg.Locals {
+			c.f("%s := %s\n", v.Param, v.Ref)
+		}
+
+		if msg.IsCompiled() {
+			if msg.UsesTemplates() {
+				c.render(ctx, dataCode(msg))
+			}
+			c.s("var b strings.Builder\n")
+			if len(msg.CustomTemplates) > 0 {
+				c.render(ctx, pluralFormCode(msg))
+				c.s("switch {\n")
+				for i, ct := range msg.CustomTemplates {
+					c.f("case %s:\n", ct.Expression)
+					if ct.Compiled != nil {
+						c.f("%s\n", ct.Compiled.Code)
+					} else {
+						c.render(ctx, executeFallbackCode(msg.TemplateRef(fmt.Sprintf("%sCustom%d", msg.MethodName, i))))
+					}
+				}
+				c.s("default:\n")
+				c.render(ctx, compiledDftCode(msg))
+				c.s("}\n")
+			} else {
+				c.render(ctx, compiledDftCode(msg))
+			}
+			c.s("return b.String(), nil\n}\n")
+			return
+		}
+
+		c.render(ctx, dataCode(msg))
+		switch {
+		case msg.LazyTemplates && len(msg.CustomTemplates) > 0:
+			c.f("tmpls := load%sTemplates()\nvar load func() (*template.Template, error)\n", msg.CamelLang)
+			c.render(ctx, pluralFormCode(msg))
+			c.s("switch {\n")
+			for i, ct := range msg.CustomTemplates {
+				c.f("case %s:\nload = tmpls.%sCustom%d\n", ct.Expression, msg.MethodName, i)
+			}
+			c.f("default:\nload = tmpls.%sDft\n}\ntmpl, err := load()\n", msg.MethodName)
+			c.s("if err != nil {\nreturn \"\", err\n}\n")
+		case msg.LazyTemplates:
+			c.f("tmpl, err := load%sTemplates().%sDft()\n", msg.CamelLang, msg.MethodName)
+			c.s("if err != nil {\nreturn \"\", err\n}\n")
+		case len(msg.CustomTemplates) > 0:
+			c.s("var tmpl *template.Template\n")
+			c.render(ctx, pluralFormCode(msg))
+			c.s("switch {\n")
+			for i, ct := range msg.CustomTemplates {
+				c.f("case %s:\ntmpl = t.%sCustom%d\n", ct.Expression, msg.MethodName, i)
+			}
+			c.f("default:\ntmpl = t.%sDft\n}\n", msg.MethodName)
+		default:
+			c.f("var tmpl *template.Template\ntmpl = t.%sDft\n", msg.MethodName)
+		}
+		c.s("var buf bytes.Buffer\nif err := tmpl.Execute(&buf, data); err != nil {\nreturn \"\", err\n}\nreturn buf.String(), nil\n}\n")
+	})
+}
+
+// pluralFormCode declares the plural form selecting the custom templates of msg, if any.
+func pluralFormCode(msg MessageData) templ.Component {
+	return component(func(_ context.Context, c *codeWriter) {
+		if pf := msg.PluralForm; pf != nil {
+			c.f("%s := pluralForm(plural.%s, %sTag, %s)\n", pf.Var, pf.Rules, snaker.ForceLowerCamelIdentifier(msg.CamelLang), pf.Ref)
+		}
+	})
+}
+
+func devTranslatorCode(tr TranslationData) templ.Component {
+	return component(func(ctx context.Context, c *codeWriter) {
+		c.f("\ntype dev%s struct {\nsource *i18ndev.Source\n}\n", tr.CamelLang)
+		for _, msg := range tr.Messages {
+			c.f("\n// %s renders a translated message from the current translation files.\nfunc (t *dev%s) %[1]s(%[3]s) (string, error) {\n", msg.MethodName, tr.CamelLang, msg.Args)
+			for _, v := range msg.Locals {
+				c.f("%s := %s\n", v.Param, v.Ref)
+			}
+			c.render(ctx, dataCode(msg))
+			if len(msg.CustomTemplates) > 0 {
+				c.s("condition := \"\"\n")
+				c.render(ctx, pluralFormCode(msg))
+				c.s("switch {\n")
+				conditions := make([]string, 0, len(msg.CustomTemplates))
+				for _, ct := range msg.CustomTemplates {
+					c.f("case %s:\ncondition = %s\n", ct.Expression, strconv.Quote(ct.Condition()))
+					conditions = append(conditions, ", "+strconv.Quote(ct.Condition()))
+				}
+				c.f("}\ntmpl, err := t.source.Template(\"%s\", %s, condition%s)\n", tr.Lang, strconv.Quote(msg.ID), strings.Join(conditions, ""))
+			} else {
+				c.f("tmpl, err := t.source.Template(\"%s\", %s, \"\")\n", tr.Lang, strconv.Quote(msg.ID))
+			}
+			c.s("if err != nil {\nreturn \"\", err\n}\nvar buf bytes.Buffer\nif err := tmpl.Execute(&buf, data); err != nil {\nreturn \"\", err\n}\nreturn buf.String(), nil\n}\n")
+		}
+	})
+}
+
+func dataCode(msg MessageData) templ.Component {
+	return component(func(_ context.Context, c *codeWriter) {
+		if msg.ArgsType != "" {
+			c.f("data := %s\n", msg.CallArgs)
+			return
+		}
+		c.s("data := struct {\n")
+		for _, v := range msg.Vars {
+			c.f("%s %s\n", v.Name, v.Type)
+		}
+		c.s("}{\n")
+		for _, v := range msg.Vars {
+			c.f("%s: %s,\n", v.Name, v.Param)
+		}
+		c.s("}\n")
+	})
+}
+
+func compiledDftCode(msg MessageData) templ.Component {
+	return component(func(ctx context.Context, c *codeWriter) {
+		if msg.CompiledDft != nil {
+			c.f("%s\n", msg.CompiledDft.Code)
+			return
+		}
+		c.render(ctx, executeFallbackCode(msg.TemplateRef(msg.MethodName+"Dft")))
+	})
+}
+
+func executeFallbackCode(ref TemplateRef) templ.Component {
+	return component(func(_ context.Context, c *codeWriter) {
+		if ref.Lazy {
+			c.f("tmpl, err := %s()\nif err != nil {\nreturn \"\", err\n}\nif err := tmpl.Execute(&b, data); err != nil {\nreturn \"\", err\n}\n", ref.Ref)
+			return
+		}
+		c.f("if err := %s.Execute(&b, data); err != nil {\nreturn \"\", err\n}\n", ref.Ref)
+	})
+}
+
+const memoCacheCode = `
+// DefaultMemoCacheSize is the default maximum number of messages in a MemoCache.
+const DefaultMemoCacheSize = 1024
+
+// MemoCache is a concurrency-safe, size-bounded LRU cache of rendered messages.
+// It may be shared by memoized translators of different languages.
+type MemoCache struct {
+mu        sync.Mutex
+size      int
+ttl       time.Duration
+now       func() time.Time
+entries   map[string]*list.Element
+lru       *list.List
+hits      uint64
+misses    uint64
+evictions uint64
+}
+
+type memoEntry struct {
+key     string
+value   string
+expires time.Time
+}
+
+// MemoStats holds MemoCache statistics.
+type MemoStats struct {
+Hits      uint64
+Misses    uint64
+Evictions uint64
+Len       int
+}
+
+// MemoCacheOption configures a MemoCache.
+type MemoCacheOption func(*MemoCache)
+
+// WithMemoSize sets the maximum number of cached messages.
+// It panics if size is not positive, since the cache is always bounded.
+func WithMemoSize(size int) MemoCacheOption {
+if size <= 0 {
+panic(fmt.Sprintf("memo cache size must be positive, got %d", size))
+}
+return func(c *MemoCache) {
+c.size = size
+}
+}
+
+// WithMemoTTL sets how long rendered messages are cached. Zero means no expiration.
+func WithMemoTTL(ttl time.Duration) MemoCacheOption {
+return func(c *MemoCache) {
+c.ttl = ttl
+}
+}
+
+// WithMemoClock sets the clock entries expire by. It defaults to time.Now.
+func WithMemoClock(now func() time.Time) MemoCacheOption {
+return func(c *MemoCache) {
+c.now = now
+}
+}
+
+// NewMemoCache initializes a MemoCache holding up to DefaultMemoCacheSize messages without expiration.
+func NewMemoCache(opts ...MemoCacheOption) *MemoCache {
+c := &MemoCache{
+size:    DefaultMemoCacheSize,
+now:     time.Now,
+entries: make(map[string]*list.Element),
+lru:     list.New(),
+}
+for _, o := range opts {
+o(c)
+}
+return c
+}
+
+// Stats returns cache statistics.
+func (c *MemoCache) Stats() MemoStats {
+c.mu.Lock()
+defer c.mu.Unlock()
+return MemoStats{Hits: c.hits, Misses: c.misses, Evictions: c.evictions, Len: c.lru.Len()}
+}
+
+func (c *MemoCache) get(key string) (string, bool) {
+c.mu.Lock()
+defer c.mu.Unlock()
+el, ok := c.entries[key]
+if !ok {
+c.misses++
+return "", false
+}
+entry := el.Value.(*memoEntry)
+if c.ttl > 0 && c.now().After(entry.expires) {
+c.lru.Remove(el)
+delete(c.entries, key)
+c.misses++
+return "", false
+}
+c.lru.MoveToFront(el)
+c.hits++
+return entry.value, true
+}
+
+func (c *MemoCache) add(key, value string) {
+c.mu.Lock()
+defer c.mu.Unlock()
+var expires time.Time
+if c.ttl > 0 {
+expires = c.now().Add(c.ttl)
+}
+if el, ok := c.entries[key]; ok {
+el.Value = &memoEntry{key: key, value: value, expires: expires}
+c.lru.MoveToFront(el)
+return
+}
+c.entries[key] = c.lru.PushFront(&memoEntry{key: key, value: value, expires: expires})
+for c.lru.Len() > c.size {
+oldest := c.lru.Back()
+c.lru.Remove(oldest)
+delete(c.entries, oldest.Value.(*memoEntry).key)
+c.evictions++
+}
+}
+
+// MemoizedTranslator wraps a Translator with a cache.
+type MemoizedTranslator struct {
+lang       Lang
+translator Translator
+cache      *MemoCache
+}
+
+// NewMemoizedTranslator initializes a memoized Translator with its own MemoCache with default options.
+func NewMemoizedTranslator(translator Translator) *MemoizedTranslator {
+return NewMemoizedTranslatorWithCache("", translator, nil)
+}
+
+// NewMemoizedTranslatorWithCache initializes a memoized Translator for lang,
+// whose cache may be shared with memoized translators of other languages.
+// A new MemoCache with default options is used if cache is nil.
+func NewMemoizedTranslatorWithCache(lang Lang, translator Translator, cache *MemoCache) *MemoizedTranslator {
+if cache == nil {
+cache = NewMemoCache()
+}
+return &MemoizedTranslator{
+lang:       lang,
+translator: translator,
+cache:      cache,
+}
+}
+
+// NewMemoizedTranslators wraps all translators with a shared cache.
+// A new MemoCache with default options is used if cache is nil.
+func NewMemoizedTranslators(tt map[Lang]Translator, cache *MemoCache) map[Lang]Translator {
+if cache == nil {
+cache = NewMemoCache()
+}
+memoized := make(map[Lang]Translator, len(tt))
+for lang, t := range tt {
+memoized[lang] = NewMemoizedTranslatorWithCache(lang, t, cache)
+}
+return memoized
+}
+`
+
+const keyTranslatorCode = `
+// KeyTranslator renders message ids and arguments instead of translated text,
+// e.g. my_greeting{count=3,name=Bob}, so tests don't depend on wording.
+type KeyTranslator struct{}
+
+// NewKeyTranslator initializes a KeyTranslator.
+func NewKeyTranslator() KeyTranslator {
+return KeyTranslator{}
+}
+`
+
+const recordingTranslatorCode = `
+// TranslatorCall is a Translator method call recorded by RecordingTranslator.
+type TranslatorCall struct {
+ID MessageID
+// Args holds arguments by variable name.
+Args map[string]any
+}
+
+// RecordingTranslator records calls for assertions, rendering them with KeyTranslator.
+// It is safe for concurrent use.
+type RecordingTranslator struct {
+mu    sync.Mutex
+calls []TranslatorCall
+}
+
+// NewRecordingTranslator initializes a RecordingTranslator.
+func NewRecordingTranslator() *RecordingTranslator {
+return &RecordingTranslator{}
+}
+
+// Calls returns the recorded calls in order.
+func (r *RecordingTranslator) Calls() []TranslatorCall {
+r.mu.Lock()
+defer r.mu.Unlock()
+return slices.Clone(r.calls)
+}
+
+// Reset forgets recorded calls.
+func (r *RecordingTranslator) Reset() {
+r.mu.Lock()
+defer r.mu.Unlock()
+r.calls = nil
+}
+
+func (r *RecordingTranslator) record(id MessageID, args map[string]any) {
+r.mu.Lock()
+defer r.mu.Unlock()
+r.calls = append(r.calls, TranslatorCall{ID: id, Args: args})
+}
+`
+
+const registerLocaleCode = `
+// locales holds the languages compiled into the binary, registered by each language file.
+var locales = map[Lang]locale{}
+
+func registerLocale(lang Lang, l locale) struct{} {
+locales[lang] = l
+return struct{}{}
+}
+
+// NewTranslators initializes translators for all languages compiled into the binary.
+func NewTranslators() map[Lang]Translator {
+tt := make(map[Lang]Translator, len(locales))
+for lang, l := range locales {
+tt[lang] = l.new()
+}
+return tt
+}
+`
+
+const langContextCode = `
+var (
+defaultTranslators = sync.OnceValue(NewTranslators)
+// installedTranslators holds the translators set with SetTranslators, if any.
+installedTranslators atomic.Pointer[map[Lang]Translator]
+)
+
+// SetTranslators installs the translators used by T when the context carries none, by Render
+// and by the Error method of message errors, e.g. memoized, development or test translators.
+// They default to NewTranslators, which a nil map restores.
+func SetTranslators(tt map[Lang]Translator) {
+if tt == nil {
+installedTranslators.Store(nil)
+return
+}
+installedTranslators.Store(&tt)
+}
+
+// translators returns the translators set with SetTranslators, or NewTranslators.
+func translators() map[Lang]Translator {
+if tt := installedTranslators.Load(); tt != nil {
+return *tt
+}
+return defaultTranslators()
+}
+
+type (
+langContextKey        struct{}
+translatorsContextKey struct{}
+)
+
+// WithLang returns a copy of ctx carrying lang.
+func WithLang(ctx context.Context, lang Lang) context.Context {
+return context.WithValue(ctx, langContextKey{}, lang)
+}
+
+// LangFromContext returns the language carried by ctx, or BaseLang if none is set.
+func LangFromContext(ctx context.Context) Lang {
+if lang, ok := ctx.Value(langContextKey{}).(Lang); ok {
+return lang
+}
+return BaseLang
+}
+`
+
+const splitMatcherCode = `
+var (
+// matcherLangs holds languages compiled into the binary in Matcher order, starting with BaseLang.
+matcherLangs []Lang
+matcherTags  []language.Tag
+)
+
+// Matcher matches language preferences against languages compiled into the binary, defaulting to BaseLang.
+var Matcher language.Matcher
+
+func init() {
+matcherLangs = []Lang{BaseLang}
+matcherTags = []language.Tag{locales[BaseLang].tag}
+for _, lang := range slices.Sorted(maps.Keys(locales)) {
+if lang != BaseLang {
+matcherLangs = append(matcherLangs, lang)
+matcherTags = append(matcherTags, locales[lang].tag)
+}
+}
+Matcher = language.NewMatcher(matcherTags)
+}
+`
+
+const matchLangCode = `
+// MatchLang returns the best available language for an Accept-Language header value.
+// It returns BaseLang with language.No confidence if nothing matches.
+func MatchLang(acceptLanguage string) (Lang, language.Confidence) {
+tags, _, _ := language.ParseAcceptLanguage(acceptLanguage)
+_, i, conf := Matcher.Match(tags...)
+return matcherLangs[i], conf
+}
+
+// ParseLang returns the available language for the given BCP 47 tag, e.g. "es".
+func ParseLang(s string) (Lang, bool) {
+tag, err := language.Parse(s)
+if err != nil {
+return "", false
+}
+for i, t := range matcherTags {
+if t == tag {
+return matcherLangs[i], true
+}
+}
+return "", false
+}
+`
+
+const translatorFromContextCode = `
+// WithTranslators returns a copy of ctx carrying the translators T uses instead of those set with SetTranslators.
+func WithTranslators(ctx context.Context, tt map[Lang]Translator) context.Context {
+return context.WithValue(ctx, translatorsContextKey{}, tt)
+}
+
+// T returns the translator for the language carried by ctx, from the translators carried by ctx
+// or else those set with SetTranslators.
+// It falls back to BaseLang if no language is set or the language is not available.
+func T(ctx context.Context) Translator {
+tt, ok := ctx.Value(translatorsContextKey{}).(map[Lang]Translator)
+if !ok {
+tt = translators()
+}
+if t, ok := tt[LangFromContext(ctx)]; ok {
+return t
+}
+return tt[BaseLang]
+}
+`
+
+const renderTypesCode = `
+// UnknownMessageError is returned by Render for an unknown message id.
+type UnknownMessageError struct {
+ID MessageID
+}
+
+func (e *UnknownMessageError) Error() string {
+return fmt.Sprintf("unknown message %q", e.ID)
+}
+
+// MissingArgumentError is returned by Render when a message argument is missing.
+type MissingArgumentError struct {
+ID  MessageID
+Arg string
+}
+
+func (e *MissingArgumentError) Error() string {
+return fmt.Sprintf("message %q: missing argument %s", e.ID, e.Arg)
+}
+
+// InvalidArgumentError is returned by Render when a message argument has the wrong type.
+type InvalidArgumentError struct {
+ID    MessageID
+Arg   string
+Type  string
+Value any
+}
+
+func (e *InvalidArgumentError) Error() string {
+return fmt.Sprintf("message %q: argument %s must be %s, got %T", e.ID, e.Arg, e.Type, e.Value)
+}
+
+// Render renders a message by id with arguments keyed by variable name.
+// It uses the translator for lang set with SetTranslators, falling back to BaseLang if lang is not available.
+func Render(lang Lang, id MessageID, args map[string]any) (string, error) {
+tt := translators()
+t, ok := tt[lang]
+if !ok {
+t = tt[BaseLang]
+}
+switch id {
+`
+
+const renderArgCode = `}
+return "", &UnknownMessageError{ID: id}
+}
+
+func renderArg[T any](id MessageID, args map[string]any, name string) (T, error) {
+var zero T
+v, ok := args[name]
+if !ok {
+return zero, &MissingArgumentError{ID: id, Arg: name}
+}
+typ := reflect.TypeFor[T]()
+if v == nil && typ.Kind() == reflect.Interface {
+return zero, nil
+}
+arg, ok := v.(T)
+if !ok {
+return zero, &InvalidArgumentError{ID: id, Arg: name, Type: typ.String(), Value: v}
+}
+return arg, nil
+}
+`
+
+const middlewareCode = `
+// LangSource resolves a language from a request.
+type LangSource struct {
+// Resolve returns the language of r, if any.
+Resolve func(r *http.Request) (Lang, bool)
+// Headers are the request headers Resolve reads, listed in the Vary response header
+// so that shared caches don't serve a response in one language to requests resolving another.
+Headers []string
+}
+
+// QueryLangSource resolves the language from a query parameter, e.g. ?lang=es.
+func QueryLangSource(param string) LangSource {
+return LangSource{
+Resolve: func(r *http.Request) (Lang, bool) {
+return ParseLang(r.URL.Query().Get(param))
+},
+}
+}
+
+// CookieLangSource resolves the language from a cookie.
+func CookieLangSource(name string) LangSource {
+return LangSource{
+Resolve: func(r *http.Request) (Lang, bool) {
+c, err := r.Cookie(name)
+if err != nil {
+return "", false
+}
+return ParseLang(c.Value)
+},
+Headers: []string{"Cookie"},
+}
+}
+
+// AcceptLanguageSource resolves the language from the Accept-Language header.
+func AcceptLanguageSource() LangSource {
+return LangSource{
+Resolve: func(r *http.Request) (Lang, bool) {
+lang, conf := MatchLang(r.Header.Get("Accept-Language"))
+return lang, conf != language.No
+},
+Headers: []string{"Accept-Language"},
+}
+}
+
+// LangMiddleware stores the language resolved by the first matching source in the request context,
+// retrievable with LangFromContext and T, and sets the Content-Language response header.
+// BaseLang is used if no source matches.
+// The Vary response header lists the headers read by the sources tried.
+// Sources default to QueryLangSource("lang"), CookieLangSource("lang") and AcceptLanguageSource().
+func LangMiddleware(sources ...LangSource) func(http.Handler) http.Handler {
+if len(sources) == 0 {
+sources = []LangSource{QueryLangSource("lang"), CookieLangSource("lang"), AcceptLanguageSource()}
+}
+return func(next http.Handler) http.Handler {
+return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+lang := BaseLang
+var vary []string
+for _, source := range sources {
+for _, h := range source.Headers {
+if !slices.Contains(vary, h) {
+vary = append(vary, h)
+}
+}
+if l, ok := source.Resolve(r); ok {
+lang = l
+break
+}
+}
+for _, h := range vary {
+w.Header().Add("Vary", h)
+}
+w.Header().Set("Content-Language", string(lang))
+next.ServeHTTP(w, r.WithContext(WithLang(r.Context(), lang)))
+})
+}
+}
+`
+
+const escapeHTMLCode = `
+var htmlReplacer = strings.NewReplacer(
+"\x00", "\uFFFD",
+` + "`\"`" + `, "&#34;",
+"&", "&amp;",
+"'", "&#39;",
+"+", "&#43;",
+"<", "&lt;",
+">", "&gt;",
+)
+
+// escapeHTML escapes s the same way html/template escapes values in text.
+func escapeHTML(s string) string {
+return htmlReplacer.Replace(s)
+}
+`
+
+const lazyTemplateCode = `
+// lazyTemplate returns a function parsing a template on first use.
+func lazyTemplate(name, text string) func() (*template.Template, error) {
+return sync.OnceValues(func() (*template.Template, error) {
+return template.New(name).Parse(text)
+})
+}
+`
+
+const lazyTemplateFuncsCode = `
+// lazyTemplate returns a function parsing a template on first use.
+func lazyTemplate(name, text string, funcs template.FuncMap) func() (*template.Template, error) {
+return sync.OnceValues(func() (*template.Template, error) {
+return template.New(name).Funcs(funcs).Parse(text)
+})
+}
+`
+
+const pluralFormFuncCode = `
+// pluralForm returns the CLDR plural form of the integer n in tag by rules.
+func pluralForm[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr](rules *plural.Rules, tag language.Tag, n T) plural.Form {
+abs := uint64(n)
+if n < 0 {
+abs = -abs
+}
+// Rules only depend on the last digits of integers beyond the int range of 32-bit platforms,
+// kept above the values rules match exactly.
+if abs > 1<<31-1 {
+abs = abs%10_000_000 + 10_000_000
+}
+return rules.MatchPlural(tag, int(abs), 0, 0, 0, 0)
+}
+`
+
+const preloadCode = `
+// NewTranslatorsE initializes all translators after parsing all templates,
+// returning an error for any invalid template instead of failing on first use.
+func NewTranslatorsE() (map[Lang]Translator, error) {
+if err := Preload(); err != nil {
+return nil, err
+}
+return NewTranslators(), nil
+}
+
+// Preload parses all templates of all languages.
+func Preload() error {
+`
+
+const examplesTestRunCode = `}
+for _, tc := range tests {
+t.Run(tc.name, func(t *testing.T) {
+t.Parallel()
+
+tr, ok := tt[tc.lang]
+if !ok {
+t.Skipf("%s is not compiled in", tc.lang)
+}
+got, err := tc.render(tr)
+if err != nil {
+t.Fatalf("unexpected error: %v", err)
+}
+if got != tc.want {
+t.Errorf("got %q, want %q", got, tc.want)
+}
+})
+}
+}
+`
@@ -2,15 +2,7 @@
 // Code generated by i18ngo. DO NOT EDIT.
 package {{ .PkgName }}
 
-import (
-    "fmt"
-    "bytes"
-    "html/template"
-    "time"
-
-    "github.com/kofalt/go-memoize"
-    "github.com/patrickmn/go-cache"
-)
+{{ template "imports" . }}
 
 // Translator is implemented by all language translators.
 type Translator interface {
@@ -19,6 +11,27 @@ type Translator interface {
 {{- end }}
 }
 
+{{- if .ArgsStructs }}
+{{- range .Messages }}
+
+// {{ .ArgsType }} holds the arguments of {{ .MethodName }}.
+type {{ .ArgsType }} struct {
+    {{- range .Vars }}
+    {{ .Name }} {{ .Type }}
+    {{- end }}
+}
+{{- end }}
+{{- end }}
+
+// MessageID identifies a message.
+type MessageID string
+
+const (
+{{- range .Messages }}
+    MessageID{{ .MethodName }} MessageID = "{{ .ID }}"
+{{- end }}
+)
+
 // Lang represents available translated languages.
 type Lang string
 
@@ -28,38 +41,303 @@ const (
 {{- end }}
 )
 
+{{- if .Memoized }}
+
+// DefaultMemoCacheSize is the default maximum number of messages in a MemoCache.
+const DefaultMemoCacheSize = 1024
+
+// MemoCache is a concurrency-safe, size-bounded LRU cache of rendered messages.
+// It may be shared by memoized translators of different languages.
+type MemoCache struct {
+    mu        sync.Mutex
+    size      int
+    ttl       time.Duration
+    now       func() time.Time
+    entries   map[string]*list.Element
+    lru       *list.List
+    hits      uint64
+    misses    uint64
+    evictions uint64
+}
+
+type memoEntry struct {
+    key     string
+    value   string
+    expires time.Time
+}
+
+// MemoStats holds MemoCache statistics.
+type MemoStats struct {
+    Hits      uint64
+    Misses    uint64
+    Evictions uint64
+    Len       int
+}
+
+// MemoCacheOption configures a MemoCache.
+type MemoCacheOption func(*MemoCache)
+
+// WithMemoSize sets the maximum number of cached messages.
+// It panics if size is not positive, since the cache is always bounded.
+func WithMemoSize(size int) MemoCacheOption {
+    if size <= 0 {
+        panic(fmt.Sprintf("memo cache size must be positive, got %d", size))
+    }
+    return func(c *MemoCache) {
+        c.size = size
+    }
+}
+
+// WithMemoTTL sets how long rendered messages are cached. Zero means no expiration.
+func WithMemoTTL(ttl time.Duration) MemoCacheOption {
+    return func(c *MemoCache) {
+        c.ttl = ttl
+    }
+}
+
+// WithMemoClock sets the clock entries expire by. It defaults to time.Now.
+func WithMemoClock(now func() time.Time) MemoCacheOption {
+    return func(c *MemoCache) {
+        c.now = now
+    }
+}
+
+// NewMemoCache initializes a MemoCache holding up to DefaultMemoCacheSize messages without expiration.
+func NewMemoCache(opts ...MemoCacheOption) *MemoCache {
+    c := &MemoCache{
+        size:    DefaultMemoCacheSize,
+        now:     time.Now,
+        entries: make(map[string]*list.Element),
+        lru:     list.New(),
+    }
+    for _, o := range opts {
+        o(c)
+    }
+    return c
+}
+
+// Stats returns cache statistics.
+func (c *MemoCache) Stats() MemoStats {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return MemoStats{Hits: c.hits, Misses: c.misses, Evictions: c.evictions, Len: c.lru.Len()}
+}
+
+func (c *MemoCache) get(key string) (string, bool) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    el, ok := c.entries[key]
+    if !ok {
+        c.misses++
+        return "", false
+    }
+    entry := el.Value.(*memoEntry)
+    if c.ttl > 0 && c.now().After(entry.expires) {
+        c.lru.Remove(el)
+        delete(c.entries, key)
+        c.misses++
+        return "", false
+    }
+    c.lru.MoveToFront(el)
+    c.hits++
+    return entry.value, true
+}
+
+func (c *MemoCache) add(key, value string) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    var expires time.Time
+    if c.ttl > 0 {
+        expires = c.now().Add(c.ttl)
+    }
+    if el, ok := c.entries[key]; ok {
+        el.Value = &memoEntry{key: key, value: value, expires: expires}
+        c.lru.MoveToFront(el)
+        return
+    }
+    c.entries[key] = c.lru.PushFront(&memoEntry{key: key, value: value, expires: expires})
+    for c.lru.Len() > c.size {
+        oldest := c.lru.Back()
+        c.lru.Remove(oldest)
+        delete(c.entries, oldest.Value.(*memoEntry).key)
+        c.evictions++
+    }
+}
 
 // MemoizedTranslator wraps a Translator with a cache.
 type MemoizedTranslator struct {
+    lang       Lang
     translator Translator
-    cache      *memoize.Memoizer
+    cache      *MemoCache
 }
 
-// NewMemoizedTranslator initializes a memoized Translator.
+// NewMemoizedTranslator initializes a memoized Translator with its own MemoCache with default options.
 func NewMemoizedTranslator(translator Translator) *MemoizedTranslator {
-    cache := memoize.NewMemoizer(cache.NoExpiration, 1*time.Hour)
+    return NewMemoizedTranslatorWithCache("", translator, nil)
+}
+
+// NewMemoizedTranslatorWithCache initializes a memoized Translator for lang,
+// whose cache may be shared with memoized translators of other languages.
+// A new MemoCache with default options is used if cache is nil.
+func NewMemoizedTranslatorWithCache(lang Lang, translator Translator, cache *MemoCache) *MemoizedTranslator {
+    if cache == nil {
+        cache = NewMemoCache()
+    }
     return &MemoizedTranslator{
+        lang:       lang,
         translator: translator,
         cache:      cache,
     }
 }
 
+// NewMemoizedTranslators wraps all translators with a shared cache.
+// A new MemoCache with default options is used if cache is nil.
+func NewMemoizedTranslators(tt map[Lang]Translator, cache *MemoCache) map[Lang]Translator {
+    if cache == nil {
+        cache = NewMemoCache()
+    }
+    memoized := make(map[Lang]Translator, len(tt))
+    for lang, t := range tt {
+        memoized[lang] = NewMemoizedTranslatorWithCache(lang, t, cache)
+    }
+    return memoized
+}
+
 {{ range .Messages }}
+{{- if .RelativeTime }}
+// {{.MethodName}} computes the message without caching it, since its relative times change as time passes.
+func (m *MemoizedTranslator) {{.MethodName}}({{.Args}}) (string, error) {
+    return m.translator.{{.MethodName}}({{ .CallArgs }})
+}
+{{- else }}
 // {{.MethodName}} checks the cache or computes the message if not already cached.
 func (m *MemoizedTranslator) {{.MethodName}}({{.Args}}) (string, error) {
-    cacheKey := fmt.Sprintf("{{.CamelLang}}:{{.MethodName}}:{{- range .Vars }}%v:{{- end }}", {{- range .Vars }}{{- .Param}}, {{- end }})
-
-    result, _, _ := m.cache.Memoize(cacheKey, func() (interface{}, error) {
-        return m.translator.{{.MethodName}}({{- range .Vars }}{{- .Param}}, {{- end }})
-    })
+    cacheKey := fmt.Sprintf("%s\x00{{.MethodName}}{{- range .Vars }}\x00%#v{{- end }}", m.lang, {{- range .Vars }}{{- .Ref}}, {{- end }})
+    if rendered, ok := m.cache.get(cacheKey); ok {
+        return rendered, nil
+    }
 
-    if err, ok := result.(error); ok {
+    rendered, err := m.translator.{{.MethodName}}({{ .CallArgs }})
+    if err != nil {
         return "", err
     }
-    return result.(string), nil
+    m.cache.add(cacheKey, rendered)
+    return rendered, nil
+}
+{{- end }}
+{{- end }}
+{{- end }}
+
+{{- if .TestTranslators }}
+
+// KeyTranslator renders message ids and arguments instead of translated text,
+// e.g. my_greeting{count=3,name=Bob}, so tests don't depend on wording.
+type KeyTranslator struct{}
+
+// NewKeyTranslator initializes a KeyTranslator.
+func NewKeyTranslator() KeyTranslator {
+    return KeyTranslator{}
+}
+
+{{- range .Messages }}
+
+// {{.MethodName}} renders the message id and arguments.
+func (KeyTranslator) {{.MethodName}}({{.Args}}) (string, error) {
+    {{- if .Vars }}
+    return fmt.Sprintf("{{ .ID }}{ {{- range $i, $v := .Vars }}{{ if $i }},{{ end }}{{ $v.Param }}=%v{{ end -}} }", {{- range .Vars }}{{ .Ref }}, {{- end }}), nil
+    {{- else }}
+    return "{{ .ID }}{}", nil
+    {{- end }}
+}
+{{- end }}
+
+// TranslatorCall is a Translator method call recorded by RecordingTranslator.
+type TranslatorCall struct {
+    ID MessageID
+    // Args holds arguments by variable name.
+    Args map[string]any
 }
+
+// RecordingTranslator records calls for assertions, rendering them with KeyTranslator.
+// It is safe for concurrent use.
+type RecordingTranslator struct {
+    mu    sync.Mutex
+    calls []TranslatorCall
+}
+
+// NewRecordingTranslator initializes a RecordingTranslator.
+func NewRecordingTranslator() *RecordingTranslator {
+    return &RecordingTranslator{}
+}
+
+// Calls returns the recorded calls in order.
+func (r *RecordingTranslator) Calls() []TranslatorCall {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    return slices.Clone(r.calls)
+}
+
+// Reset forgets recorded calls.
+func (r *RecordingTranslator) Reset() {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.calls = nil
+}
+
+func (r *RecordingTranslator) record(id MessageID, args map[string]any) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.calls = append(r.calls, TranslatorCall{ID: id, Args: args})
+}
+
+{{- range .Messages }}
+
+// {{.MethodName}} records the call and renders the message id and arguments.
+func (r *RecordingTranslator) {{.MethodName}}({{.Args}}) (string, error) {
+    r.record(MessageID{{ .MethodName }}, map[string]any{
+    {{- range .Vars }}
+        "{{ .Name }}": {{ .Ref }},
+    {{- end }}
+    })
+    return KeyTranslator{}.{{.MethodName}}({{ .CallArgs }})
+}
+{{- end }}
 {{- end }}
 
+{{- if .SplitLocales }}
+
+// locale holds the constructors of a language compiled into the binary.
+type locale struct {
+    {{- if .LangMatcher }}
+    tag language.Tag
+    {{- end }}
+    new func() Translator
+    {{- if .LazyTemplates }}
+    preload func() error
+    {{- end }}
+    {{- if .DevTranslators }}
+    newDev func(source *i18ndev.Source) Translator
+    {{- end }}
+}
+
+// locales holds the languages compiled into the binary, registered by each language file.
+var locales = map[Lang]locale{}
+
+func registerLocale(lang Lang, l locale) struct{} {
+    locales[lang] = l
+    return struct{}{}
+}
+
+// NewTranslators initializes translators for all languages compiled into the binary.
+func NewTranslators() map[Lang]Translator {
+    tt := make(map[Lang]Translator, len(locales))
+    for lang, l := range locales {
+        tt[lang] = l.new()
+    }
+    return tt
+}
+{{- else }}
+
 // NewTranslators initializes all translators.
 func NewTranslators() map[Lang]Translator {
     return map[Lang]Translator{
@@ -68,48 +346,675 @@ func NewTranslators() map[Lang]Translator {
     {{- end }}
     }
 }
+{{- end }}
+
+// BaseLang is the fallback language when none is set.
+const BaseLang = Lang{{ .BaseLang.CamelLang }}
+
+var (
+    defaultTranslators = sync.OnceValue(NewTranslators)
+    // installedTranslators holds the translators set with SetTranslators, if any.
+    installedTranslators atomic.Pointer[map[Lang]Translator]
+)
+
+// SetTranslators installs the translators used by T when the context carries none, by Render
+// and by the Error method of message errors, e.g. memoized, development or test translators.
+// They default to NewTranslators, which a nil map restores.
+func SetTranslators(tt map[Lang]Translator) {
+    if tt == nil {
+        installedTranslators.Store(nil)
+        return
+    }
+    installedTranslators.Store(&tt)
+}
+
+// translators returns the translators set with SetTranslators, or NewTranslators.
+func translators() map[Lang]Translator {
+    if tt := installedTranslators.Load(); tt != nil {
+        return *tt
+    }
+    return defaultTranslators()
+}
+
+type (
+    langContextKey        struct{}
+    translatorsContextKey struct{}
+)
+
+// WithLang returns a copy of ctx carrying lang.
+func WithLang(ctx context.Context, lang Lang) context.Context {
+    return context.WithValue(ctx, langContextKey{}, lang)
+}
+
+// LangFromContext returns the language carried by ctx, or BaseLang if none is set.
+func LangFromContext(ctx context.Context) Lang {
+    if lang, ok := ctx.Value(langContextKey{}).(Lang); ok {
+        return lang
+    }
+    return BaseLang
+}
+
+{{- if .LangMatcher }}
+{{- if .SplitLocales }}
+
+var (
+    // matcherLangs holds languages compiled into the binary in Matcher order, starting with BaseLang.
+    matcherLangs []Lang
+    matcherTags  []language.Tag
+)
+
+// Matcher matches language preferences against languages compiled into the binary, defaulting to BaseLang.
+var Matcher language.Matcher
+
+func init() {
+    matcherLangs = []Lang{BaseLang}
+    matcherTags = []language.Tag{locales[BaseLang].tag}
+    for _, lang := range slices.Sorted(maps.Keys(locales)) {
+        if lang != BaseLang {
+            matcherLangs = append(matcherLangs, lang)
+            matcherTags = append(matcherTags, locales[lang].tag)
+        }
+    }
+    Matcher = language.NewMatcher(matcherTags)
+}
+{{- else }}
+
+var (
+    // matcherLangs holds available languages in Matcher order, starting with BaseLang.
+    matcherLangs = []Lang{
+        BaseLang,
+    {{- range .Langs }}
+        {{- if ne .Lang $.BaseLang.Lang }}
+        Lang{{ .CamelLang }},
+        {{- end }}
+    {{- end }}
+    }
+    matcherTags = []language.Tag{
+        language.MustParse("{{ .BaseLang.Lang }}"),
+    {{- range .Langs }}
+        {{- if ne .Lang $.BaseLang.Lang }}
+        language.MustParse("{{ .Lang }}"),
+        {{- end }}
+    {{- end }}
+    }
+)
+
+// Matcher matches language preferences against available languages, defaulting to BaseLang.
+var Matcher = language.NewMatcher(matcherTags)
+{{- end }}
+
+// MatchLang returns the best available language for an Accept-Language header value.
+// It returns BaseLang with language.No confidence if nothing matches.
+func MatchLang(acceptLanguage string) (Lang, language.Confidence) {
+    tags, _, _ := language.ParseAcceptLanguage(acceptLanguage)
+    _, i, conf := Matcher.Match(tags...)
+    return matcherLangs[i], conf
+}
+
+// ParseLang returns the available language for the given BCP 47 tag, e.g. "es".
+func ParseLang(s string) (Lang, bool) {
+    tag, err := language.Parse(s)
+    if err != nil {
+        return "", false
+    }
+    for i, t := range matcherTags {
+        if t == tag {
+            return matcherLangs[i], true
+        }
+    }
+    return "", false
+}
+{{- end }}
+
+// WithTranslators returns a copy of ctx carrying the translators T uses instead of those set with SetTranslators.
+func WithTranslators(ctx context.Context, tt map[Lang]Translator) context.Context {
+    return context.WithValue(ctx, translatorsContextKey{}, tt)
+}
+
+// T returns the translator for the language carried by ctx, from the translators carried by ctx
+// or else those set with SetTranslators.
+// It falls back to BaseLang if no language is set or the language is not available.
+func T(ctx context.Context) Translator {
+    tt, ok := ctx.Value(translatorsContextKey{}).(map[Lang]Translator)
+    if !ok {
+        tt = translators()
+    }
+    if t, ok := tt[LangFromContext(ctx)]; ok {
+        return t
+    }
+    return tt[BaseLang]
+}
+
+{{- if .Render }}
+
+// UnknownMessageError is returned by Render for an unknown message id.
+type UnknownMessageError struct {
+    ID MessageID
+}
+
+func (e *UnknownMessageError) Error() string {
+    return fmt.Sprintf("unknown message %q", e.ID)
+}
+
+// MissingArgumentError is returned by Render when a message argument is missing.
+type MissingArgumentError struct {
+    ID  MessageID
+    Arg string
+}
+
+func (e *MissingArgumentError) Error() string {
+    return fmt.Sprintf("message %q: missing argument %s", e.ID, e.Arg)
+}
+
+// InvalidArgumentError is returned by Render when a message argument has the wrong type.
+type InvalidArgumentError struct {
+    ID    MessageID
+    Arg   string
+    Type  string
+    Value any
+}
+
+func (e *InvalidArgumentError) Error() string {
+    return fmt.Sprintf("message %q: argument %s must be %s, got %T", e.ID, e.Arg, e.Type, e.Value)
+}
+
+// Render renders a message by id with arguments keyed by variable name.
+// It uses the translator for lang set with SetTranslators, falling back to BaseLang if lang is not available.
+func Render(lang Lang, id MessageID, args map[string]any) (string, error) {
+    tt := translators()
+    t, ok := tt[lang]
+    if !ok {
+        t = tt[BaseLang]
+    }
+    switch id {
+    {{- range .Messages }}
+    case MessageID{{ .MethodName }}:
+        {{- range .Vars }}
+        arg{{ .Name }}, err := renderArg[{{ .Type }}](id, args, "{{ .Name }}")
+        if err != nil {
+            return "", err
+        }
+        {{- end }}
+        return t.{{ .MethodName }}({{ .CallWith "arg" }})
+    {{- end }}
+    }
+    return "", &UnknownMessageError{ID: id}
+}
+
+func renderArg[T any](id MessageID, args map[string]any, name string) (T, error) {
+    var zero T
+    v, ok := args[name]
+    if !ok {
+        return zero, &MissingArgumentError{ID: id, Arg: name}
+    }
+    typ := reflect.TypeFor[T]()
+    if v == nil && typ.Kind() == reflect.Interface {
+        return zero, nil
+    }
+    arg, ok := v.(T)
+    if !ok {
+        return zero, &InvalidArgumentError{ID: id, Arg: name, Type: typ.String(), Value: v}
+    }
+    return arg, nil
+}
+{{- end }}
+
+{{- range .Messages }}
+{{- if .ErrorType }}
+
+// {{ .ErrorSentinel }} is matched by {{ .ErrorType }} through errors.Is.
+var {{ .ErrorSentinel }} = errors.New("{{ .ID }}")
+
+// {{ .ErrorType }} is a localizable error for the {{ .ID }} message.
+type {{ .ErrorType }} struct {
+    {{- range .Vars }}
+    {{ .Name }} {{ .Type }}
+    {{- end }}
+}
+
+// Error renders the message in BaseLang with the translators set with SetTranslators as plain text,
+// unescaping the HTML-escaped values.
+func (e *{{ .ErrorType }}) Error() string {
+    return html.UnescapeString(e.Localize(translators()[BaseLang]))
+}
+
+// Is reports whether target is {{ .ErrorSentinel }}.
+func (e *{{ .ErrorType }}) Is(target error) bool {
+    return target == {{ .ErrorSentinel }}
+}
+
+// Localize renders the message with the given translator.
+// It falls back to the message id if rendering fails.
+func (e *{{ .ErrorType }}) Localize(t Translator) string {
+    s, err := t.{{ .MethodName }}({{ .CallWith "e." }})
+    if err != nil {
+        return string(MessageID{{ .MethodName }})
+    }
+    return s
+}
+{{- end }}
+{{- end }}
+
+{{- if .LangMiddleware }}
+
+// LangSource resolves a language from a request.
+type LangSource struct {
+    // Resolve returns the language of r, if any.
+    Resolve func(r *http.Request) (Lang, bool)
+    // Headers are the request headers Resolve reads, listed in the Vary response header
+    // so that shared caches don't serve a response in one language to requests resolving another.
+    Headers []string
+}
+
+// QueryLangSource resolves the language from a query parameter, e.g. ?lang=es.
+func QueryLangSource(param string) LangSource {
+    return LangSource{
+        Resolve: func(r *http.Request) (Lang, bool) {
+            return ParseLang(r.URL.Query().Get(param))
+        },
+    }
+}
+
+// CookieLangSource resolves the language from a cookie.
+func CookieLangSource(name string) LangSource {
+    return LangSource{
+        Resolve: func(r *http.Request) (Lang, bool) {
+            c, err := r.Cookie(name)
+            if err != nil {
+                return "", false
+            }
+            return ParseLang(c.Value)
+        },
+        Headers: []string{"Cookie"},
+    }
+}
+
+// AcceptLanguageSource resolves the language from the Accept-Language header.
+func AcceptLanguageSource() LangSource {
+    return LangSource{
+        Resolve: func(r *http.Request) (Lang, bool) {
+            lang, conf := MatchLang(r.Header.Get("Accept-Language"))
+            return lang, conf != language.No
+        },
+        Headers: []string{"Accept-Language"},
+    }
+}
+
+// LangMiddleware stores the language resolved by the first matching source in the request context,
+// retrievable with LangFromContext and T, and sets the Content-Language response header.
+// BaseLang is used if no source matches.
+// The Vary response header lists the headers read by the sources tried.
+// Sources default to QueryLangSource("lang"), CookieLangSource("lang") and AcceptLanguageSource().
+func LangMiddleware(sources ...LangSource) func(http.Handler) http.Handler {
+    if len(sources) == 0 {
+        sources = []LangSource{QueryLangSource("lang"), CookieLangSource("lang"), AcceptLanguageSource()}
+    }
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            lang := BaseLang
+            var vary []string
+            for _, source := range sources {
+                for _, h := range source.Headers {
+                    if !slices.Contains(vary, h) {
+                        vary = append(vary, h)
+                    }
+                }
+                if l, ok := source.Resolve(r); ok {
+                    lang = l
+                    break
+                }
+            }
+            for _, h := range vary {
+                w.Header().Add("Vary", h)
+            }
+            w.Header().Set("Content-Language", string(lang))
+            next.ServeHTTP(w, r.WithContext(WithLang(r.Context(), lang)))
+        })
+    }
+}
+{{- end }}
+
+{{- if .TemplComponents }}
+{{- range .Messages }}
+
+// {{ .MethodName }}C renders {{ .MethodName }} as HTML with the translator for the language carried by the context.
+// Rendering errors are returned by Render.
+func {{ .MethodName }}C({{ .Args }}) templ.Component {
+    return templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+        s, err := T(ctx).{{ .MethodName }}({{ .CallArgs }})
+        if err != nil {
+            return err
+        }
+        _, err = io.WriteString(w, s)
+        return err
+    })
+}
+{{- end }}
+{{- end }}
+
+{{- if .CompiledTemplates }}
+
+var htmlReplacer = strings.NewReplacer(
+    "\x00", "\uFFFD",
+    `"`, "&#34;",
+    "&", "&amp;",
+    "'", "&#39;",
+    "+", "&#43;",
+    "<", "&lt;",
+    ">", "&gt;",
+)
+
+// escapeHTML escapes s the same way html/template escapes values in text.
+func escapeHTML(s string) string {
+    return htmlReplacer.Replace(s)
+}
+{{- end }}
+
+{{- if .Funcs }}
+
+{{ funcsCode }}
+{{- end }}
+
+{{- if .Plurals }}
+
+// pluralForm returns the CLDR plural form of the integer n in tag by rules.
+func pluralForm[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr](rules *plural.Rules, tag language.Tag, n T) plural.Form {
+    abs := uint64(n)
+    if n < 0 {
+        abs = -abs
+    }
+    // Rules only depend on the last digits of integers beyond the int range of 32-bit platforms,
+    // kept above the values rules match exactly.
+    if abs > 1<<31-1 {
+        abs = abs%10_000_000 + 10_000_000
+    }
+    return rules.MatchPlural(tag, int(abs), 0, 0, 0, 0)
+}
+{{- end }}
 
+{{- if .LazyTemplates }}
+
+// lazyTemplate returns a function parsing a template on first use.
+{{- if .Funcs }}
+func lazyTemplate(name, text string, funcs template.FuncMap) func() (*template.Template, error) {
+    return sync.OnceValues(func() (*template.Template, error) {
+        return template.New(name).Funcs(funcs).Parse(text)
+    })
+}
+{{- else }}
+func lazyTemplate(name, text string) func() (*template.Template, error) {
+    return sync.OnceValues(func() (*template.Template, error) {
+        return template.New(name).Parse(text)
+    })
+}
+{{- end }}
+
+// NewTranslatorsE initializes all translators after parsing all templates,
+// returning an error for any invalid template instead of failing on first use.
+func NewTranslatorsE() (map[Lang]Translator, error) {
+    if err := Preload(); err != nil {
+        return nil, err
+    }
+    return NewTranslators(), nil
+}
+
+// Preload parses all templates of all languages.
+func Preload() error {
+{{- if .SplitLocales }}
+    for _, lang := range slices.Sorted(maps.Keys(locales)) {
+        if err := locales[lang].preload(); err != nil {
+            return fmt.Errorf("%s: %w", lang, err)
+        }
+    }
+{{- else }}
+{{- range .Langs }}
+    if err := load{{ .CamelLang }}Templates().preload(); err != nil {
+        return fmt.Errorf("{{ .Lang }}: %w", err)
+    }
+{{- end }}
+{{- end }}
+    return nil
+}
+{{- end }}
+
+{{- if not .SplitLocales }}
 {{- range .Translations }}
-type {{camelCase .CamelLang}} struct {
+{{- template "translator" . }}
+{{- end }}
+{{- end }}
+
+{{- if .DevTranslators }}
+
+// NewDevTranslators initializes translators reading translation files in fsys at runtime,
+// parsing templates again whenever the files change, for local development.
+// Only message text is live: regenerate after changing variables or custom template expressions.
+func NewDevTranslators(fsys fs.FS, opts ...i18ndev.Option) map[Lang]Translator {
+    source := i18ndev.NewSource(fsys, ".", opts...)
+    {{- if .SplitLocales }}
+    tt := make(map[Lang]Translator, len(locales))
+    for lang, l := range locales {
+        tt[lang] = l.newDev(source)
+    }
+    return tt
+    {{- else }}
+    return map[Lang]Translator{
+    {{- range .Langs }}
+        Lang{{.CamelLang}}: &dev{{.CamelLang}}{source: source},
+    {{- end }}
+    }
+    {{- end }}
+}
+{{- if not .SplitLocales }}
+{{- range .Translations }}
+{{- template "devTranslator" . }}
+{{- end }}
+{{- end }}
+{{- end }}
+
+{{- define "imports" }}
+import (
+    "container/list"
+    "context"
+    "errors"
+    "fmt"
+    "bytes"
+    "html"
+    "html/template"
+    "io"
+    "io/fs"
+    "maps"
+    "net/http"
+    "reflect"
+    "slices"
+    "strconv"
+    "strings"
+    "sync"
+    "sync/atomic"
+    "time"
+{{- if .Funcs }}
+{{- range funcsImports }}
+    "{{ . }}"
+{{- end }}
+{{- end }}
+
+    "golang.org/x/text/feature/plural"
+    "golang.org/x/text/language"
+
+    "github.com/a-h/templ"
+    "github.com/danicc097/i18ngo/i18ndev"
+{{- if .Imports }}
+{{ range .Imports }}
+    "{{ . }}"
+{{- end }}
+{{- end }}
+)
+{{- end }}
+
+{{- define "translator" }}
+{{- $lazy := .LazyTemplates }}
+{{- $lang := camelCase .CamelLang }}
+{{- if .Funcs }}
+
+// {{ $lang }}Funcs holds template functions formatting values by {{ .Lang }} rules.
+var {{ $lang }}Funcs = templateFuncs(language.MustParse("{{ .Lang }}"), {{ localeFormatsCode .Lang }})
+{{ end }}
+{{- if .Plurals }}
+
+// {{ $lang }}Tag selects plural forms by {{ .Lang }} rules.
+var {{ $lang }}Tag = language.MustParse("{{ .Lang }}")
+{{ end }}
+{{- if $lazy }}
+type {{ $lang }} struct{}
+
+func new{{.CamelLang}}() *{{ $lang }} {
+    return &{{ $lang }}{}
+}
+
+// {{ $lang }}Templates holds {{ .Lang }} templates, each parsed on first use.
+type {{ $lang }}Templates struct {
+    {{- range .Messages }}
+    {{- if not .CompiledDft }}
+    {{ .MethodName }}Dft func() (*template.Template, error)
+    {{- end }}
+    {{- $methodName := .MethodName }}
+    {{- range $index, $ct := .CustomTemplates }}
+    {{- if not $ct.Compiled }}
+    {{ $methodName }}Custom{{ $index }} func() (*template.Template, error)
+    {{- end }}
+    {{- end }}
+    {{- end }}
+}
+
+// load{{ .CamelLang }}Templates initializes {{ .Lang }} templates on first use of the language.
+var load{{ .CamelLang }}Templates = sync.OnceValue(func() *{{ $lang }}Templates {
+    return &{{ $lang }}Templates{
+    {{- range .Messages }}
+        {{- if not .CompiledDft }}
+        {{ .MethodName }}Dft: lazyTemplate("{{ .MethodName }}", {{ quote .Template }}{{ if $.Funcs }}, {{ $lang }}Funcs{{ end }}),
+        {{- end }}
+        {{- $methodName := .MethodName }}
+        {{- range $index, $ct := .CustomTemplates }}
+        {{- if not $ct.Compiled }}
+        {{ $methodName }}Custom{{ $index }}: lazyTemplate("{{ $methodName }}Custom{{ $index }}", {{ quote $ct.Template }}{{ if $.Funcs }}, {{ $lang }}Funcs{{ end }}),
+        {{- end }}
+        {{- end }}
+    {{- end }}
+    }
+})
+
+func (t *{{ $lang }}Templates) preload() error {
+    for _, load := range []func() (*template.Template, error){
     {{- range .Messages }}
+        {{- if not .CompiledDft }}
+        t.{{ .MethodName }}Dft,
+        {{- end }}
+        {{- $methodName := .MethodName }}
+        {{- range $index, $ct := .CustomTemplates }}
+        {{- if not $ct.Compiled }}
+        t.{{ $methodName }}Custom{{ $index }},
+        {{- end }}
+        {{- end }}
+    {{- end }}
+    } {
+        if _, err := load(); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+{{- else }}
+type {{ $lang }} struct {
+    {{- range .Messages }}
+    {{- if not .CompiledDft }}
     {{ .MethodName }}Dft *template.Template
+    {{- end }}
     {{- if .CustomTemplates }}
         {{- $methodName := .MethodName }}
         {{- range $index, $ct := .CustomTemplates }}
+        {{- if not $ct.Compiled }}
     {{ $methodName }}Custom{{ $index }} *template.Template
         {{- end }}
+        {{- end }}
     {{- end }}
     {{- end }}
 }
 
-func new{{.CamelLang}}() *{{camelCase .CamelLang}} {
-    return &{{camelCase .CamelLang}}{
+func new{{.CamelLang}}() *{{ $lang }} {
+    return &{{ $lang }}{
     {{- range .Messages }}
-        {{ .MethodName }}Dft: template.Must(template.New("{{ .MethodName }}").Parse("{{ .Template }}")),
+        {{- if not .CompiledDft }}
+        {{ .MethodName }}Dft: template.Must(template.New("{{ .MethodName }}"){{ if $.Funcs }}.Funcs({{ $lang }}Funcs){{ end }}.Parse({{ quote .Template }})),
+        {{- end }}
         {{- if .CustomTemplates }}
             {{- $methodName := .MethodName }}
             {{- range $index, $ct := .CustomTemplates }}
-        {{ $methodName }}Custom{{ $index }}: template.Must(template.New("{{ $methodName }}Custom{{ $index }}").Parse("{{ $ct.Template }}")),
+            {{- if not $ct.Compiled }}
+        {{ $methodName }}Custom{{ $index }}: template.Must(template.New("{{ $methodName }}Custom{{ $index }}"){{ if $.Funcs }}.Funcs({{ $lang }}Funcs){{ end }}.Parse({{ quote $ct.Template }})),
+            {{- end }}
             {{- end }}
         {{- end }}
     {{- end }}
     }
 }
+{{- end }}
 
 {{- range .Messages }}
 // {{.MethodName}} renders a properly translated message.
-func (t *{{camelCase .CamelLang}}) {{.MethodName}}({{.Args}}) (string, error) {
-    data := struct {
-    {{- range .Vars }}
-        {{.Name}} {{.Type}}
+func (t *{{ $lang }}) {{.MethodName}}({{.Args}}) (string, error) {
+    {{- range .Locals }}
+    {{ .Param }} := {{ .Ref }}
     {{- end }}
-    }{
-    {{- range .Vars }}
-        {{.Name}}: {{.Param}},
+    {{- if .IsCompiled }}
+    {{- if .UsesTemplates }}
+    {{- template "data" . }}
+    {{- end }}
+    var b strings.Builder
+    {{- if .CustomTemplates }}
+    {{- template "pluralForm" . }}
+    switch {
+        {{- $msg := . }}
+        {{- range $index, $ct := .CustomTemplates }}
+    case {{ $ct.Expression }}:
+        {{- if $ct.Compiled }}
+        {{ $ct.Compiled.Code }}
+        {{- else }}
+        {{- template "executeFallback" (printf "%sCustom%d" $msg.MethodName $index | $msg.TemplateRef) }}
+        {{- end }}
+        {{- end }}
+    default:
+        {{- template "compiledDft" . }}
+    }
+    {{- else }}
+    {{- template "compiledDft" . }}
+    {{- end }}
+    return b.String(), nil
+    {{- else }}
+    {{- template "data" . }}
+    {{- if .LazyTemplates }}
+    {{- if .CustomTemplates }}
+    tmpls := load{{ .CamelLang }}Templates()
+    var load func() (*template.Template, error)
+    {{- template "pluralForm" . }}
+    switch {
+        {{- $methodName := .MethodName }}
+        {{- range $index, $ct := .CustomTemplates }}
+    case {{ $ct.Expression }}:
+        load = tmpls.{{ $methodName }}Custom{{ $index }}
+        {{- end }}
+    default:
+        load = tmpls.{{ .MethodName }}Dft
+    }
+    tmpl, err := load()
+    {{- else }}
+    tmpl, err := load{{ .CamelLang }}Templates().{{ .MethodName }}Dft()
     {{- end }}
+    if err != nil {
+        return "", err
     }
+    {{- else }}
     var tmpl *template.Template
     {{- if .CustomTemplates }}
+    {{- template "pluralForm" . }}
     switch {
         {{- $methodName := .MethodName }}
         {{- range $index, $ct := .CustomTemplates }}
@@ -122,11 +1027,187 @@ func (t *{{camelCase .CamelLang}}) {{.MethodName}}({{.Args}}) (string, error) {
     {{- else }}
     tmpl = t.{{ .MethodName }}Dft
     {{- end }}
+    {{- end }}
     var buf bytes.Buffer
     if err := tmpl.Execute(&buf, data); err != nil {
         return "", err
     }
     return buf.String(), nil
+    {{- end }}
 }
 {{- end }}
 {{- end }}
+
+{{- define "pluralForm" }}
+{{- with .PluralForm }}
+    {{ .Var }} := pluralForm(plural.{{ .Rules }}, {{ camelCase $.CamelLang }}Tag, {{ .Ref }})
+{{- end }}
+{{- end }}
+
+{{- define "devTranslator" }}
+{{- $lang := .Lang }}
+
+type dev{{ .CamelLang }} struct {
+    source *i18ndev.Source
+}
+
+{{- range .Messages }}
+
+// {{.MethodName}} renders a translated message from the current translation files.
+func (t *dev{{ .CamelLang }}) {{.MethodName}}({{.Args}}) (string, error) {
+    {{- range .Locals }}
+    {{ .Param }} := {{ .Ref }}
+    {{- end }}
+    {{- template "data" . }}
+    {{- if .CustomTemplates }}
+    condition := ""
+    {{- template "pluralForm" . }}
+    switch {
+        {{- range .CustomTemplates }}
+    case {{ .Expression }}:
+        condition = {{ quote .Condition }}
+        {{- end }}
+    }
+    tmpl, err := t.source.Template("{{ $lang }}", {{ quote .ID }}, condition
+        {{- range .CustomTemplates }}, {{ quote .Condition }}{{ end }})
+    {{- else }}
+    tmpl, err := t.source.Template("{{ $lang }}", {{ quote .ID }}, "")
+    {{- end }}
+    if err != nil {
+        return "", err
+    }
+    var buf bytes.Buffer
+    if err := tmpl.Execute(&buf, data); err != nil {
+        return "", err
+    }
+    return buf.String(), nil
+}
+{{- end }}
+{{- end }}
+
+{{- define "locale" }}
+{{- if .BuildTag }}//go:build {{ .BuildTag }}
+
+{{ end -}}
+// Code generated by i18ngo. DO NOT EDIT.
+package {{ .Root.PkgName }}
+
+{{ template "imports" .Root }}
+{{- with .Translation }}
+
+var _ = registerLocale(Lang{{ .CamelLang }}, locale{
+    {{- if $.Root.LangMatcher }}
+    tag: language.MustParse("{{ .Lang }}"),
+    {{- end }}
+    new: func() Translator { return new{{ .CamelLang }}() },
+    {{- if .LazyTemplates }}
+    preload: func() error { return load{{ .CamelLang }}Templates().preload() },
+    {{- end }}
+    {{- if .DevTranslators }}
+    newDev: func(source *i18ndev.Source) Translator { return &dev{{ .CamelLang }}{source: source} },
+    {{- end }}
+})
+{{- template "translator" . }}
+{{- if .DevTranslators }}
+{{- template "devTranslator" . }}
+{{- end }}
+{{- end }}
+{{- end }}
+
+{{- define "examplesTest" }}
+// Code generated by i18ngo. DO NOT EDIT.
+package {{ .PkgName }}
+
+import (
+    "testing"
+{{- if .Imports }}
+{{ range .Imports }}
+    "{{ . }}"
+{{- end }}
+{{- end }}
+)
+
+func TestExamples(t *testing.T) {
+    t.Parallel()
+
+    tt := NewTranslators()
+    tests := []struct {
+        name   string
+        lang   Lang
+        render func(Translator) (string, error)
+        want   string
+    }{
+    {{- range .Translations }}
+    {{- $tr := . }}
+    {{- range .Messages }}
+    {{- $msg := . }}
+    {{- range $i, $ex := .Examples }}
+        {
+            name:   "{{ $tr.Lang }}/{{ $msg.ID }}/{{ $i }}",
+            lang:   Lang{{ $tr.CamelLang }},
+            render: func(tr Translator) (string, error) { return tr.{{ $msg.MethodName }}({{ $ex.Call }}) },
+            want:   {{ quote $ex.Want }},
+        },
+    {{- end }}
+    {{- end }}
+    {{- end }}
+    }
+    for _, tc := range tests {
+        t.Run(tc.name, func(t *testing.T) {
+            t.Parallel()
+
+            tr, ok := tt[tc.lang]
+            if !ok {
+                t.Skipf("%s is not compiled in", tc.lang)
+            }
+            got, err := tc.render(tr)
+            if err != nil {
+                t.Fatalf("unexpected error: %v", err)
+            }
+            if got != tc.want {
+                t.Errorf("got %q, want %q", got, tc.want)
+            }
+        })
+    }
+}
+{{- end }}
+
+{{- define "data" }}
+    {{- if .ArgsType }}
+    data := {{ .CallArgs }}
+    {{- else }}
+    data := struct {
+    {{- range .Vars }}
+        {{.Name}} {{.Type}}
+    {{- end }}
+    }{
+    {{- range .Vars }}
+        {{.Name}}: {{.Param}},
+    {{- end }}
+    }
+    {{- end }}
+{{- end }}
+
+{{- define "compiledDft" }}
+    {{- if .CompiledDft }}
+    {{ .CompiledDft.Code }}
+    {{- else }}
+    {{- template "executeFallback" (printf "%sDft" .MethodName | .TemplateRef) }}
+    {{- end }}
+{{- end }}
+
+{{- define "executeFallback" }}
+    {{- if .Lazy }}
+        tmpl, err := {{ .Ref }}()
+        if err != nil {
+            return "", err
+        }
+        if err := tmpl.Execute(&b, data); err != nil {
+            return "", err
+        }
+    {{- else }}
+        if err := {{ .Ref }}.Execute(&b, data); err != nil {
+            return "", err
+        }
+    {{- end }}
+{{- end }}
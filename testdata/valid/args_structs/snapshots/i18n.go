@@ -0,0 +1,695 @@
+// Code generated by i18ngo. DO NOT EDIT.
+package translations
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"fmt"
+	"html/template"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Translator is implemented by all language translators.
+type Translator interface {
+	CommandUsage(args_ CommandUsageArgs) (string, error)
+	InboxSummary(args InboxSummaryArgs) (string, error)
+	MyGreeting(args MyGreetingArgs) (string, error)
+	Progress(args ProgressArgs) (string, error)
+	Welcome(args WelcomeArgs) (string, error)
+}
+
+// CommandUsageArgs holds the arguments of CommandUsage.
+type CommandUsageArgs struct {
+	Args    int
+	Command string
+}
+
+// InboxSummaryArgs holds the arguments of InboxSummary.
+type InboxSummaryArgs struct {
+	HasUnread bool
+	Name      string
+	Unread    int
+}
+
+// MyGreetingArgs holds the arguments of MyGreeting.
+type MyGreetingArgs struct {
+	Count int
+	Name  string
+}
+
+// ProgressArgs holds the arguments of Progress.
+type ProgressArgs struct {
+	Name  string
+	Ratio float64
+}
+
+// WelcomeArgs holds the arguments of Welcome.
+type WelcomeArgs struct {
+	Name string
+}
+
+// MessageID identifies a message.
+type MessageID string
+
+const (
+	MessageIDCommandUsage MessageID = "command_usage"
+	MessageIDInboxSummary MessageID = "inbox_summary"
+	MessageIDMyGreeting   MessageID = "my_greeting"
+	MessageIDProgress     MessageID = "progress"
+	MessageIDWelcome      MessageID = "welcome"
+)
+
+// Lang represents available translated languages.
+type Lang string
+
+const (
+	LangEn Lang = "en"
+	LangEs Lang = "es"
+)
+
+// DefaultMemoCacheSize is the default maximum number of messages in a MemoCache.
+const DefaultMemoCacheSize = 1024
+
+// MemoCache is a concurrency-safe, size-bounded LRU cache of rendered messages.
+// It may be shared by memoized translators of different languages.
+type MemoCache struct {
+	mu        sync.Mutex
+	size      int
+	ttl       time.Duration
+	now       func() time.Time
+	entries   map[string]*list.Element
+	lru       *list.List
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+type memoEntry struct {
+	key     string
+	value   string
+	expires time.Time
+}
+
+// MemoStats holds MemoCache statistics.
+type MemoStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Len       int
+}
+
+// MemoCacheOption configures a MemoCache.
+type MemoCacheOption func(*MemoCache)
+
+// WithMemoSize sets the maximum number of cached messages.
+// It panics if size is not positive, since the cache is always bounded.
+func WithMemoSize(size int) MemoCacheOption {
+	if size <= 0 {
+		panic(fmt.Sprintf("memo cache size must be positive, got %d", size))
+	}
+	return func(c *MemoCache) {
+		c.size = size
+	}
+}
+
+// WithMemoTTL sets how long rendered messages are cached. Zero means no expiration.
+func WithMemoTTL(ttl time.Duration) MemoCacheOption {
+	return func(c *MemoCache) {
+		c.ttl = ttl
+	}
+}
+
+// WithMemoClock sets the clock entries expire by. It defaults to time.Now.
+func WithMemoClock(now func() time.Time) MemoCacheOption {
+	return func(c *MemoCache) {
+		c.now = now
+	}
+}
+
+// NewMemoCache initializes a MemoCache holding up to DefaultMemoCacheSize messages without expiration.
+func NewMemoCache(opts ...MemoCacheOption) *MemoCache {
+	c := &MemoCache{
+		size:    DefaultMemoCacheSize,
+		now:     time.Now,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// Stats returns cache statistics.
+func (c *MemoCache) Stats() MemoStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return MemoStats{Hits: c.hits, Misses: c.misses, Evictions: c.evictions, Len: c.lru.Len()}
+}
+
+func (c *MemoCache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return "", false
+	}
+	entry := el.Value.(*memoEntry)
+	if c.ttl > 0 && c.now().After(entry.expires) {
+		c.lru.Remove(el)
+		delete(c.entries, key)
+		c.misses++
+		return "", false
+	}
+	c.lru.MoveToFront(el)
+	c.hits++
+	return entry.value, true
+}
+
+func (c *MemoCache) add(key, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var expires time.Time
+	if c.ttl > 0 {
+		expires = c.now().Add(c.ttl)
+	}
+	if el, ok := c.entries[key]; ok {
+		el.Value = &memoEntry{key: key, value: value, expires: expires}
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&memoEntry{key: key, value: value, expires: expires})
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoEntry).key)
+		c.evictions++
+	}
+}
+
+// MemoizedTranslator wraps a Translator with a cache.
+type MemoizedTranslator struct {
+	lang       Lang
+	translator Translator
+	cache      *MemoCache
+}
+
+// NewMemoizedTranslator initializes a memoized Translator with its own MemoCache with default options.
+func NewMemoizedTranslator(translator Translator) *MemoizedTranslator {
+	return NewMemoizedTranslatorWithCache("", translator, nil)
+}
+
+// NewMemoizedTranslatorWithCache initializes a memoized Translator for lang,
+// whose cache may be shared with memoized translators of other languages.
+// A new MemoCache with default options is used if cache is nil.
+func NewMemoizedTranslatorWithCache(lang Lang, translator Translator, cache *MemoCache) *MemoizedTranslator {
+	if cache == nil {
+		cache = NewMemoCache()
+	}
+	return &MemoizedTranslator{
+		lang:       lang,
+		translator: translator,
+		cache:      cache,
+	}
+}
+
+// NewMemoizedTranslators wraps all translators with a shared cache.
+// A new MemoCache with default options is used if cache is nil.
+func NewMemoizedTranslators(tt map[Lang]Translator, cache *MemoCache) map[Lang]Translator {
+	if cache == nil {
+		cache = NewMemoCache()
+	}
+	memoized := make(map[Lang]Translator, len(tt))
+	for lang, t := range tt {
+		memoized[lang] = NewMemoizedTranslatorWithCache(lang, t, cache)
+	}
+	return memoized
+}
+
+// CommandUsage checks the cache or computes the message if not already cached.
+func (m *MemoizedTranslator) CommandUsage(args_ CommandUsageArgs) (string, error) {
+	cacheKey := fmt.Sprintf("%s\x00CommandUsage\x00%#v\x00%#v", m.lang, args_.Args, args_.Command)
+	if rendered, ok := m.cache.get(cacheKey); ok {
+		return rendered, nil
+	}
+
+	rendered, err := m.translator.CommandUsage(args_)
+	if err != nil {
+		return "", err
+	}
+	m.cache.add(cacheKey, rendered)
+	return rendered, nil
+}
+
+// InboxSummary checks the cache or computes the message if not already cached.
+func (m *MemoizedTranslator) InboxSummary(args InboxSummaryArgs) (string, error) {
+	cacheKey := fmt.Sprintf("%s\x00InboxSummary\x00%#v\x00%#v\x00%#v", m.lang, args.HasUnread, args.Name, args.Unread)
+	if rendered, ok := m.cache.get(cacheKey); ok {
+		return rendered, nil
+	}
+
+	rendered, err := m.translator.InboxSummary(args)
+	if err != nil {
+		return "", err
+	}
+	m.cache.add(cacheKey, rendered)
+	return rendered, nil
+}
+
+// MyGreeting checks the cache or computes the message if not already cached.
+func (m *MemoizedTranslator) MyGreeting(args MyGreetingArgs) (string, error) {
+	cacheKey := fmt.Sprintf("%s\x00MyGreeting\x00%#v\x00%#v", m.lang, args.Count, args.Name)
+	if rendered, ok := m.cache.get(cacheKey); ok {
+		return rendered, nil
+	}
+
+	rendered, err := m.translator.MyGreeting(args)
+	if err != nil {
+		return "", err
+	}
+	m.cache.add(cacheKey, rendered)
+	return rendered, nil
+}
+
+// Progress checks the cache or computes the message if not already cached.
+func (m *MemoizedTranslator) Progress(args ProgressArgs) (string, error) {
+	cacheKey := fmt.Sprintf("%s\x00Progress\x00%#v\x00%#v", m.lang, args.Name, args.Ratio)
+	if rendered, ok := m.cache.get(cacheKey); ok {
+		return rendered, nil
+	}
+
+	rendered, err := m.translator.Progress(args)
+	if err != nil {
+		return "", err
+	}
+	m.cache.add(cacheKey, rendered)
+	return rendered, nil
+}
+
+// Welcome checks the cache or computes the message if not already cached.
+func (m *MemoizedTranslator) Welcome(args WelcomeArgs) (string, error) {
+	cacheKey := fmt.Sprintf("%s\x00Welcome\x00%#v", m.lang, args.Name)
+	if rendered, ok := m.cache.get(cacheKey); ok {
+		return rendered, nil
+	}
+
+	rendered, err := m.translator.Welcome(args)
+	if err != nil {
+		return "", err
+	}
+	m.cache.add(cacheKey, rendered)
+	return rendered, nil
+}
+
+// KeyTranslator renders message ids and arguments instead of translated text,
+// e.g. my_greeting{count=3,name=Bob}, so tests don't depend on wording.
+type KeyTranslator struct{}
+
+// NewKeyTranslator initializes a KeyTranslator.
+func NewKeyTranslator() KeyTranslator {
+	return KeyTranslator{}
+}
+
+// CommandUsage renders the message id and arguments.
+func (KeyTranslator) CommandUsage(args_ CommandUsageArgs) (string, error) {
+	return fmt.Sprintf("command_usage{args=%v,command=%v}", args_.Args, args_.Command), nil
+}
+
+// InboxSummary renders the message id and arguments.
+func (KeyTranslator) InboxSummary(args InboxSummaryArgs) (string, error) {
+	return fmt.Sprintf("inbox_summary{hasUnread=%v,name=%v,unread=%v}", args.HasUnread, args.Name, args.Unread), nil
+}
+
+// MyGreeting renders the message id and arguments.
+func (KeyTranslator) MyGreeting(args MyGreetingArgs) (string, error) {
+	return fmt.Sprintf("my_greeting{count=%v,name=%v}", args.Count, args.Name), nil
+}
+
+// Progress renders the message id and arguments.
+func (KeyTranslator) Progress(args ProgressArgs) (string, error) {
+	return fmt.Sprintf("progress{name=%v,ratio=%v}", args.Name, args.Ratio), nil
+}
+
+// Welcome renders the message id and arguments.
+func (KeyTranslator) Welcome(args WelcomeArgs) (string, error) {
+	return fmt.Sprintf("welcome{name=%v}", args.Name), nil
+}
+
+// TranslatorCall is a Translator method call recorded by RecordingTranslator.
+type TranslatorCall struct {
+	ID MessageID
+	// Args holds arguments by variable name.
+	Args map[string]any
+}
+
+// RecordingTranslator records calls for assertions, rendering them with KeyTranslator.
+// It is safe for concurrent use.
+type RecordingTranslator struct {
+	mu    sync.Mutex
+	calls []TranslatorCall
+}
+
+// NewRecordingTranslator initializes a RecordingTranslator.
+func NewRecordingTranslator() *RecordingTranslator {
+	return &RecordingTranslator{}
+}
+
+// Calls returns the recorded calls in order.
+func (r *RecordingTranslator) Calls() []TranslatorCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.calls)
+}
+
+// Reset forgets recorded calls.
+func (r *RecordingTranslator) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+func (r *RecordingTranslator) record(id MessageID, args map[string]any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, TranslatorCall{ID: id, Args: args})
+}
+
+// CommandUsage records the call and renders the message id and arguments.
+func (r *RecordingTranslator) CommandUsage(args_ CommandUsageArgs) (string, error) {
+	r.record(MessageIDCommandUsage, map[string]any{
+		"Args":    args_.Args,
+		"Command": args_.Command,
+	})
+	return KeyTranslator{}.CommandUsage(args_)
+}
+
+// InboxSummary records the call and renders the message id and arguments.
+func (r *RecordingTranslator) InboxSummary(args InboxSummaryArgs) (string, error) {
+	r.record(MessageIDInboxSummary, map[string]any{
+		"HasUnread": args.HasUnread,
+		"Name":      args.Name,
+		"Unread":    args.Unread,
+	})
+	return KeyTranslator{}.InboxSummary(args)
+}
+
+// MyGreeting records the call and renders the message id and arguments.
+func (r *RecordingTranslator) MyGreeting(args MyGreetingArgs) (string, error) {
+	r.record(MessageIDMyGreeting, map[string]any{
+		"Count": args.Count,
+		"Name":  args.Name,
+	})
+	return KeyTranslator{}.MyGreeting(args)
+}
+
+// Progress records the call and renders the message id and arguments.
+func (r *RecordingTranslator) Progress(args ProgressArgs) (string, error) {
+	r.record(MessageIDProgress, map[string]any{
+		"Name":  args.Name,
+		"Ratio": args.Ratio,
+	})
+	return KeyTranslator{}.Progress(args)
+}
+
+// Welcome records the call and renders the message id and arguments.
+func (r *RecordingTranslator) Welcome(args WelcomeArgs) (string, error) {
+	r.record(MessageIDWelcome, map[string]any{
+		"Name": args.Name,
+	})
+	return KeyTranslator{}.Welcome(args)
+}
+
+// NewTranslators initializes all translators.
+func NewTranslators() map[Lang]Translator {
+	return map[Lang]Translator{
+		LangEn: newEn(),
+		LangEs: newEs(),
+	}
+}
+
+// BaseLang is the fallback language when none is set.
+const BaseLang = LangEn
+
+var (
+	defaultTranslators = sync.OnceValue(NewTranslators)
+	// installedTranslators holds the translators set with SetTranslators, if any.
+	installedTranslators atomic.Pointer[map[Lang]Translator]
+)
+
+// SetTranslators installs the translators used by T when the context carries none, by Render
+// and by the Error method of message errors, e.g. memoized, development or test translators.
+// They default to NewTranslators, which a nil map restores.
+func SetTranslators(tt map[Lang]Translator) {
+	if tt == nil {
+		installedTranslators.Store(nil)
+		return
+	}
+	installedTranslators.Store(&tt)
+}
+
+// translators returns the translators set with SetTranslators, or NewTranslators.
+func translators() map[Lang]Translator {
+	if tt := installedTranslators.Load(); tt != nil {
+		return *tt
+	}
+	return defaultTranslators()
+}
+
+type (
+	langContextKey        struct{}
+	translatorsContextKey struct{}
+)
+
+// WithLang returns a copy of ctx carrying lang.
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, langContextKey{}, lang)
+}
+
+// LangFromContext returns the language carried by ctx, or BaseLang if none is set.
+func LangFromContext(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(langContextKey{}).(Lang); ok {
+		return lang
+	}
+	return BaseLang
+}
+
+// WithTranslators returns a copy of ctx carrying the translators T uses instead of those set with SetTranslators.
+func WithTranslators(ctx context.Context, tt map[Lang]Translator) context.Context {
+	return context.WithValue(ctx, translatorsContextKey{}, tt)
+}
+
+// T returns the translator for the language carried by ctx, from the translators carried by ctx
+// or else those set with SetTranslators.
+// It falls back to BaseLang if no language is set or the language is not available.
+func T(ctx context.Context) Translator {
+	tt, ok := ctx.Value(translatorsContextKey{}).(map[Lang]Translator)
+	if !ok {
+		tt = translators()
+	}
+	if t, ok := tt[LangFromContext(ctx)]; ok {
+		return t
+	}
+	return tt[BaseLang]
+}
+
+var htmlReplacer = strings.NewReplacer(
+	"\x00", "\uFFFD",
+	`"`, "&#34;",
+	"&", "&amp;",
+	"'", "&#39;",
+	"+", "&#43;",
+	"<", "&lt;",
+	">", "&gt;",
+)
+
+// escapeHTML escapes s the same way html/template escapes values in text.
+func escapeHTML(s string) string {
+	return htmlReplacer.Replace(s)
+}
+
+type en struct {
+	WelcomeDft *template.Template
+}
+
+func newEn() *en {
+	return &en{
+		WelcomeDft: template.Must(template.New("Welcome").Parse("Welcome, <b>{{ .Name }}</b>!")),
+	}
+}
+
+// CommandUsage renders a properly translated message.
+func (t *en) CommandUsage(args_ CommandUsageArgs) (string, error) {
+	args := args_.Args
+	var b strings.Builder
+	switch {
+	case args == 0:
+		b.WriteString(escapeHTML(args_.Command))
+		b.WriteString(" takes no arguments.")
+	default:
+		b.WriteString(escapeHTML(args_.Command))
+		b.WriteString(" takes ")
+		b.WriteString(strconv.Itoa(args_.Args))
+		b.WriteString(" arguments.")
+	}
+	return b.String(), nil
+}
+
+// InboxSummary renders a properly translated message.
+func (t *en) InboxSummary(args InboxSummaryArgs) (string, error) {
+	var b strings.Builder
+	if args.HasUnread {
+		b.WriteString("You have ")
+		b.WriteString(escapeHTML(fmt.Sprintf("%d", args.Unread)))
+		b.WriteString(" unread items")
+	} else {
+		b.WriteString("You are all caught up")
+	}
+	b.WriteString(", ")
+	b.WriteString(escapeHTML(args.Name))
+	b.WriteString(".")
+	return b.String(), nil
+}
+
+// MyGreeting renders a properly translated message.
+func (t *en) MyGreeting(args MyGreetingArgs) (string, error) {
+	count := args.Count
+	var b strings.Builder
+	switch {
+	case count == 1:
+		b.WriteString("Hello ")
+		b.WriteString(escapeHTML(args.Name))
+		b.WriteString("! You have ")
+		b.WriteString(strconv.Itoa(args.Count))
+		b.WriteString(" message.")
+	case count == 0:
+		b.WriteString("Hello ")
+		b.WriteString(escapeHTML(args.Name))
+		b.WriteString("! You have no messages.")
+	default:
+		b.WriteString("Hello ")
+		b.WriteString(escapeHTML(args.Name))
+		b.WriteString("! You have ")
+		b.WriteString(strconv.Itoa(args.Count))
+		b.WriteString(" messages.")
+	}
+	return b.String(), nil
+}
+
+// Progress renders a properly translated message.
+func (t *en) Progress(args ProgressArgs) (string, error) {
+	var b strings.Builder
+	b.WriteString(escapeHTML(args.Name))
+	b.WriteString(" is ")
+	b.WriteString(escapeHTML(strconv.FormatFloat(args.Ratio, 'g', -1, 64)))
+	b.WriteString(" done.")
+	return b.String(), nil
+}
+
+// Welcome renders a properly translated message.
+func (t *en) Welcome(args WelcomeArgs) (string, error) {
+	data := args
+	var tmpl *template.Template
+	tmpl = t.WelcomeDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+type es struct {
+	WelcomeDft *template.Template
+}
+
+func newEs() *es {
+	return &es{
+		WelcomeDft: template.Must(template.New("Welcome").Parse("Bienvenido, <b>{{ .Name }}</b>!")),
+	}
+}
+
+// CommandUsage renders a properly translated message.
+func (t *es) CommandUsage(args_ CommandUsageArgs) (string, error) {
+	args := args_.Args
+	var b strings.Builder
+	switch {
+	case args == 0:
+		b.WriteString(escapeHTML(args_.Command))
+		b.WriteString(" no recibe argumentos.")
+	default:
+		b.WriteString(escapeHTML(args_.Command))
+		b.WriteString(" recibe ")
+		b.WriteString(strconv.Itoa(args_.Args))
+		b.WriteString(" argumentos.")
+	}
+	return b.String(), nil
+}
+
+// InboxSummary renders a properly translated message.
+func (t *es) InboxSummary(args InboxSummaryArgs) (string, error) {
+	var b strings.Builder
+	if args.HasUnread {
+		b.WriteString("Tienes ")
+		b.WriteString(escapeHTML(fmt.Sprintf("%d", args.Unread)))
+		b.WriteString(" elementos sin leer")
+	} else {
+		b.WriteString("Estás al día")
+	}
+	b.WriteString(", ")
+	b.WriteString(escapeHTML(args.Name))
+	b.WriteString(".")
+	return b.String(), nil
+}
+
+// MyGreeting renders a properly translated message.
+func (t *es) MyGreeting(args MyGreetingArgs) (string, error) {
+	count := args.Count
+	var b strings.Builder
+	switch {
+	case count == 1:
+		b.WriteString("Hola ")
+		b.WriteString(escapeHTML(args.Name))
+		b.WriteString("! Tienes ")
+		b.WriteString(strconv.Itoa(args.Count))
+		b.WriteString(" mensaje.")
+	case count == 0:
+		b.WriteString("Hola ")
+		b.WriteString(escapeHTML(args.Name))
+		b.WriteString("! No tienes ningún mensaje.")
+	default:
+		b.WriteString("Hola ")
+		b.WriteString(escapeHTML(args.Name))
+		b.WriteString("! Tienes ")
+		b.WriteString(strconv.Itoa(args.Count))
+		b.WriteString(" mensajes.")
+	}
+	return b.String(), nil
+}
+
+// Progress renders a properly translated message.
+func (t *es) Progress(args ProgressArgs) (string, error) {
+	var b strings.Builder
+	b.WriteString(escapeHTML(args.Name))
+	b.WriteString(" está al ")
+	b.WriteString(escapeHTML(strconv.FormatFloat(args.Ratio, 'g', -1, 64)))
+	b.WriteString(".")
+	return b.String(), nil
+}
+
+// Welcome renders a properly translated message.
+func (t *es) Welcome(args WelcomeArgs) (string, error) {
+	data := args
+	var tmpl *template.Template
+	tmpl = t.WelcomeDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,246 @@
+// Code generated by i18ngo. DO NOT EDIT.
+
+/** Lang represents available translated languages. */
+export type Lang = "en" | "es";
+
+/** langs holds all available languages. */
+export const langs: readonly Lang[] = ["en", "es"];
+
+/** baseLang is the fallback language when none is set. */
+export const baseLang: Lang = "en";
+
+/** CommandUsageArgs holds the arguments of commandUsage. */
+export interface CommandUsageArgs {
+  Args: number;
+  Command: string;
+}
+
+/** InboxSummaryArgs holds the arguments of inboxSummary. */
+export interface InboxSummaryArgs {
+  HasUnread: boolean;
+  Name: string;
+  Unread: number;
+}
+
+/** MyGreetingArgs holds the arguments of myGreeting. */
+export interface MyGreetingArgs {
+  Count: number;
+  Name: string;
+}
+
+/** ProgressArgs holds the arguments of progress. */
+export interface ProgressArgs {
+  Name: string;
+  Ratio: number;
+}
+
+/** WelcomeArgs holds the arguments of welcome. */
+export interface WelcomeArgs {
+  Name: string;
+}
+
+/** Translator is implemented by all language translators. */
+export interface Translator {
+  commandUsage(args_: CommandUsageArgs): string;
+  inboxSummary(args: InboxSummaryArgs): string;
+  myGreeting(args: MyGreetingArgs): string;
+  progress(args: ProgressArgs): string;
+  welcome(args: WelcomeArgs): string;
+}
+
+const htmlEscapes: Record<string, string> = {
+  "\u0000": "�",
+  '"': "&#34;",
+  "&": "&amp;",
+  "'": "&#39;",
+  "+": "&#43;",
+  "<": "&lt;",
+  ">": "&gt;",
+};
+
+/** escapeHTML escapes s the same way Go's html/template escapes values in text. */
+function escapeHTML(s: string): string {
+  return s.replace(/[\u0000"&'+<>]/g, (c) => htmlEscapes[c]);
+}
+
+/** formatFloat formats x the same way Go formats float64 values. */
+function formatFloat(x: number): string {
+  if (Number.isNaN(x)) return "NaN";
+  if (!Number.isFinite(x)) return x > 0 ? "+Inf" : "-Inf";
+  if (x === 0) return Object.is(x, -0) ? "-0" : "0";
+  const [mantissa, exponent] = x.toExponential().split("e");
+  const exp = Number(exponent);
+  if (exp < -4 || exp >= 6) {
+    return mantissa + "e" + (exp < 0 ? "-" : "+") + String(Math.abs(exp)).padStart(2, "0");
+  }
+  return String(x);
+}
+
+/** formatValue formats values of unknown type the same way Go does. */
+function formatValue(x: unknown): string {
+  if (typeof x === "number" && !Number.isInteger(x)) return formatFloat(x);
+  return String(x);
+}
+
+/** truthy matches Go template truthiness for values of unknown type. */
+function truthy(x: unknown): boolean {
+  return Array.isArray(x) ? x.length > 0 : Boolean(x);
+}
+
+/** byteLength returns the length of s in UTF-8 bytes, like Go's len. */
+function byteLength(s: string): number {
+  return new TextEncoder().encode(s).length;
+}
+
+const translatorEn: Translator = {
+  commandUsage(args_: CommandUsageArgs): string {
+    if (args_.Args === 0) {
+      let s = "";
+      s += escapeHTML(args_.Command);
+      s += " takes no arguments.";
+      return s;
+    }
+    let s = "";
+    s += escapeHTML(args_.Command);
+    s += " takes ";
+    s += escapeHTML(String(args_.Args));
+    s += " arguments.";
+    return s;
+  },
+  inboxSummary(args: InboxSummaryArgs): string {
+    let s = "";
+    if (args.HasUnread) {
+      s += "You have ";
+      s += escapeHTML(String(args.Unread));
+      s += " unread items";
+    } else {
+      s += "You are all caught up";
+    }
+    s += ", ";
+    s += escapeHTML(args.Name);
+    s += ".";
+    return s;
+  },
+  myGreeting(args: MyGreetingArgs): string {
+    if (args.Count === 1) {
+      let s = "";
+      s += "Hello ";
+      s += escapeHTML(args.Name);
+      s += "! You have ";
+      s += escapeHTML(String(args.Count));
+      s += " message.";
+      return s;
+    }
+    if (args.Count === 0) {
+      let s = "";
+      s += "Hello ";
+      s += escapeHTML(args.Name);
+      s += "! You have no messages.";
+      return s;
+    }
+    let s = "";
+    s += "Hello ";
+    s += escapeHTML(args.Name);
+    s += "! You have ";
+    s += escapeHTML(String(args.Count));
+    s += " messages.";
+    return s;
+  },
+  progress(args: ProgressArgs): string {
+    let s = "";
+    s += escapeHTML(args.Name);
+    s += " is ";
+    s += escapeHTML(formatFloat(args.Ratio));
+    s += " done.";
+    return s;
+  },
+  welcome(args: WelcomeArgs): string {
+    let s = "";
+    s += "Welcome, <b>";
+    s += escapeHTML(args.Name);
+    s += "</b>!";
+    return s;
+  },
+};
+
+const translatorEs: Translator = {
+  commandUsage(args_: CommandUsageArgs): string {
+    if (args_.Args === 0) {
+      let s = "";
+      s += escapeHTML(args_.Command);
+      s += " no recibe argumentos.";
+      return s;
+    }
+    let s = "";
+    s += escapeHTML(args_.Command);
+    s += " recibe ";
+    s += escapeHTML(String(args_.Args));
+    s += " argumentos.";
+    return s;
+  },
+  inboxSummary(args: InboxSummaryArgs): string {
+    let s = "";
+    if (args.HasUnread) {
+      s += "Tienes ";
+      s += escapeHTML(String(args.Unread));
+      s += " elementos sin leer";
+    } else {
+      s += "Estás al día";
+    }
+    s += ", ";
+    s += escapeHTML(args.Name);
+    s += ".";
+    return s;
+  },
+  myGreeting(args: MyGreetingArgs): string {
+    if (args.Count === 1) {
+      let s = "";
+      s += "Hola ";
+      s += escapeHTML(args.Name);
+      s += "! Tienes ";
+      s += escapeHTML(String(args.Count));
+      s += " mensaje.";
+      return s;
+    }
+    if (args.Count === 0) {
+      let s = "";
+      s += "Hola ";
+      s += escapeHTML(args.Name);
+      s += "! No tienes ningún mensaje.";
+      return s;
+    }
+    let s = "";
+    s += "Hola ";
+    s += escapeHTML(args.Name);
+    s += "! Tienes ";
+    s += escapeHTML(String(args.Count));
+    s += " mensajes.";
+    return s;
+  },
+  progress(args: ProgressArgs): string {
+    let s = "";
+    s += escapeHTML(args.Name);
+    s += " está al ";
+    s += escapeHTML(formatFloat(args.Ratio));
+    s += ".";
+    return s;
+  },
+  welcome(args: WelcomeArgs): string {
+    let s = "";
+    s += "Bienvenido, <b>";
+    s += escapeHTML(args.Name);
+    s += "</b>!";
+    return s;
+  },
+};
+
+/** translators holds a translator per language. */
+export const translators: Record<Lang, Translator> = {
+  "en": translatorEn,
+  "es": translatorEs,
+};
+
+/** t returns the translator for lang, falling back to baseLang if lang is not available. */
+export function t(lang: string): Translator {
+  return translators[lang as Lang] ?? translators[baseLang];
+}
@@ -0,0 +1,279 @@
+// Code generated by i18ngo. DO NOT EDIT.
+package translations
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Translator is implemented by all language translators.
+type Translator interface {
+	InboxSummary(hasUnread bool, name string, unread int) (string, error)
+	MyGreeting(count int, name string) (string, error)
+	Progress(name string, ratio float64) (string, error)
+	Welcome(name string) (string, error)
+}
+
+// MessageID identifies a message.
+type MessageID string
+
+const (
+	MessageIDInboxSummary MessageID = "inbox_summary"
+	MessageIDMyGreeting   MessageID = "my_greeting"
+	MessageIDProgress     MessageID = "progress"
+	MessageIDWelcome      MessageID = "welcome"
+)
+
+// Lang represents available translated languages.
+type Lang string
+
+const (
+	LangEn Lang = "en"
+	LangEs Lang = "es"
+)
+
+// NewTranslators initializes all translators.
+func NewTranslators() map[Lang]Translator {
+	return map[Lang]Translator{
+		LangEn: newEn(),
+		LangEs: newEs(),
+	}
+}
+
+// BaseLang is the fallback language when none is set.
+const BaseLang = LangEn
+
+var (
+	defaultTranslators = sync.OnceValue(NewTranslators)
+	// installedTranslators holds the translators set with SetTranslators, if any.
+	installedTranslators atomic.Pointer[map[Lang]Translator]
+)
+
+// SetTranslators installs the translators used by T when the context carries none, by Render
+// and by the Error method of message errors, e.g. memoized, development or test translators.
+// They default to NewTranslators, which a nil map restores.
+func SetTranslators(tt map[Lang]Translator) {
+	if tt == nil {
+		installedTranslators.Store(nil)
+		return
+	}
+	installedTranslators.Store(&tt)
+}
+
+// translators returns the translators set with SetTranslators, or NewTranslators.
+func translators() map[Lang]Translator {
+	if tt := installedTranslators.Load(); tt != nil {
+		return *tt
+	}
+	return defaultTranslators()
+}
+
+type (
+	langContextKey        struct{}
+	translatorsContextKey struct{}
+)
+
+// WithLang returns a copy of ctx carrying lang.
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, langContextKey{}, lang)
+}
+
+// LangFromContext returns the language carried by ctx, or BaseLang if none is set.
+func LangFromContext(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(langContextKey{}).(Lang); ok {
+		return lang
+	}
+	return BaseLang
+}
+
+// WithTranslators returns a copy of ctx carrying the translators T uses instead of those set with SetTranslators.
+func WithTranslators(ctx context.Context, tt map[Lang]Translator) context.Context {
+	return context.WithValue(ctx, translatorsContextKey{}, tt)
+}
+
+// T returns the translator for the language carried by ctx, from the translators carried by ctx
+// or else those set with SetTranslators.
+// It falls back to BaseLang if no language is set or the language is not available.
+func T(ctx context.Context) Translator {
+	tt, ok := ctx.Value(translatorsContextKey{}).(map[Lang]Translator)
+	if !ok {
+		tt = translators()
+	}
+	if t, ok := tt[LangFromContext(ctx)]; ok {
+		return t
+	}
+	return tt[BaseLang]
+}
+
+var htmlReplacer = strings.NewReplacer(
+	"\x00", "\uFFFD",
+	`"`, "&#34;",
+	"&", "&amp;",
+	"'", "&#39;",
+	"+", "&#43;",
+	"<", "&lt;",
+	">", "&gt;",
+)
+
+// escapeHTML escapes s the same way html/template escapes values in text.
+func escapeHTML(s string) string {
+	return htmlReplacer.Replace(s)
+}
+
+type en struct {
+	WelcomeDft *template.Template
+}
+
+func newEn() *en {
+	return &en{
+		WelcomeDft: template.Must(template.New("Welcome").Parse("Welcome, <b>{{ .Name }}</b>!")),
+	}
+}
+
+// InboxSummary renders a properly translated message.
+func (t *en) InboxSummary(hasUnread bool, name string, unread int) (string, error) {
+	var b strings.Builder
+	if hasUnread {
+		b.WriteString("You have ")
+		b.WriteString(escapeHTML(fmt.Sprintf("%d", unread)))
+		b.WriteString(" unread items")
+	} else {
+		b.WriteString("You are all caught up")
+	}
+	b.WriteString(", ")
+	b.WriteString(escapeHTML(name))
+	b.WriteString(".")
+	return b.String(), nil
+}
+
+// MyGreeting renders a properly translated message.
+func (t *en) MyGreeting(count int, name string) (string, error) {
+	var b strings.Builder
+	switch {
+	case count == 1:
+		b.WriteString("Hello ")
+		b.WriteString(escapeHTML(name))
+		b.WriteString("! You have ")
+		b.WriteString(strconv.Itoa(count))
+		b.WriteString(" message.")
+	case count == 0:
+		b.WriteString("Hello ")
+		b.WriteString(escapeHTML(name))
+		b.WriteString("! You have no messages.")
+	default:
+		b.WriteString("Hello ")
+		b.WriteString(escapeHTML(name))
+		b.WriteString("! You have ")
+		b.WriteString(strconv.Itoa(count))
+		b.WriteString(" messages.")
+	}
+	return b.String(), nil
+}
+
+// Progress renders a properly translated message.
+func (t *en) Progress(name string, ratio float64) (string, error) {
+	var b strings.Builder
+	b.WriteString(escapeHTML(name))
+	b.WriteString(" is ")
+	b.WriteString(escapeHTML(strconv.FormatFloat(ratio, 'g', -1, 64)))
+	b.WriteString(" done.")
+	return b.String(), nil
+}
+
+// Welcome renders a properly translated message.
+func (t *en) Welcome(name string) (string, error) {
+	data := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	var tmpl *template.Template
+	tmpl = t.WelcomeDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+type es struct {
+	WelcomeDft *template.Template
+}
+
+func newEs() *es {
+	return &es{
+		WelcomeDft: template.Must(template.New("Welcome").Parse("Bienvenido, <b>{{ .Name }}</b>!")),
+	}
+}
+
+// InboxSummary renders a properly translated message.
+func (t *es) InboxSummary(hasUnread bool, name string, unread int) (string, error) {
+	var b strings.Builder
+	if hasUnread {
+		b.WriteString("Tienes ")
+		b.WriteString(escapeHTML(fmt.Sprintf("%d", unread)))
+		b.WriteString(" elementos sin leer")
+	} else {
+		b.WriteString("Estás al día")
+	}
+	b.WriteString(", ")
+	b.WriteString(escapeHTML(name))
+	b.WriteString(".")
+	return b.String(), nil
+}
+
+// MyGreeting renders a properly translated message.
+func (t *es) MyGreeting(count int, name string) (string, error) {
+	var b strings.Builder
+	switch {
+	case count == 1:
+		b.WriteString("Hola ")
+		b.WriteString(escapeHTML(name))
+		b.WriteString("! Tienes ")
+		b.WriteString(strconv.Itoa(count))
+		b.WriteString(" mensaje.")
+	case count == 0:
+		b.WriteString("Hola ")
+		b.WriteString(escapeHTML(name))
+		b.WriteString("! No tienes ningún mensaje.")
+	default:
+		b.WriteString("Hola ")
+		b.WriteString(escapeHTML(name))
+		b.WriteString("! Tienes ")
+		b.WriteString(strconv.Itoa(count))
+		b.WriteString(" mensajes.")
+	}
+	return b.String(), nil
+}
+
+// Progress renders a properly translated message.
+func (t *es) Progress(name string, ratio float64) (string, error) {
+	var b strings.Builder
+	b.WriteString(escapeHTML(name))
+	b.WriteString(" está al ")
+	b.WriteString(escapeHTML(strconv.FormatFloat(ratio, 'g', -1, 64)))
+	b.WriteString(".")
+	return b.String(), nil
+}
+
+// Welcome renders a properly translated message.
+func (t *es) Welcome(name string) (string, error) {
+	data := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	var tmpl *template.Template
+	tmpl = t.WelcomeDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
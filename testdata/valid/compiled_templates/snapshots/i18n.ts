@@ -0,0 +1,187 @@
+// Code generated by i18ngo. DO NOT EDIT.
+
+/** Lang represents available translated languages. */
+export type Lang = "en" | "es";
+
+/** langs holds all available languages. */
+export const langs: readonly Lang[] = ["en", "es"];
+
+/** baseLang is the fallback language when none is set. */
+export const baseLang: Lang = "en";
+
+/** Translator is implemented by all language translators. */
+export interface Translator {
+  inboxSummary(hasUnread: boolean, name: string, unread: number): string;
+  myGreeting(count: number, name: string): string;
+  progress(name: string, ratio: number): string;
+  welcome(name: string): string;
+}
+
+const htmlEscapes: Record<string, string> = {
+  "\u0000": "�",
+  '"': "&#34;",
+  "&": "&amp;",
+  "'": "&#39;",
+  "+": "&#43;",
+  "<": "&lt;",
+  ">": "&gt;",
+};
+
+/** escapeHTML escapes s the same way Go's html/template escapes values in text. */
+function escapeHTML(s: string): string {
+  return s.replace(/[\u0000"&'+<>]/g, (c) => htmlEscapes[c]);
+}
+
+/** formatFloat formats x the same way Go formats float64 values. */
+function formatFloat(x: number): string {
+  if (Number.isNaN(x)) return "NaN";
+  if (!Number.isFinite(x)) return x > 0 ? "+Inf" : "-Inf";
+  if (x === 0) return Object.is(x, -0) ? "-0" : "0";
+  const [mantissa, exponent] = x.toExponential().split("e");
+  const exp = Number(exponent);
+  if (exp < -4 || exp >= 6) {
+    return mantissa + "e" + (exp < 0 ? "-" : "+") + String(Math.abs(exp)).padStart(2, "0");
+  }
+  return String(x);
+}
+
+/** formatValue formats values of unknown type the same way Go does. */
+function formatValue(x: unknown): string {
+  if (typeof x === "number" && !Number.isInteger(x)) return formatFloat(x);
+  return String(x);
+}
+
+/** truthy matches Go template truthiness for values of unknown type. */
+function truthy(x: unknown): boolean {
+  return Array.isArray(x) ? x.length > 0 : Boolean(x);
+}
+
+/** byteLength returns the length of s in UTF-8 bytes, like Go's len. */
+function byteLength(s: string): number {
+  return new TextEncoder().encode(s).length;
+}
+
+const translatorEn: Translator = {
+  inboxSummary(hasUnread: boolean, name: string, unread: number): string {
+    let s = "";
+    if (hasUnread) {
+      s += "You have ";
+      s += escapeHTML(String(unread));
+      s += " unread items";
+    } else {
+      s += "You are all caught up";
+    }
+    s += ", ";
+    s += escapeHTML(name);
+    s += ".";
+    return s;
+  },
+  myGreeting(count: number, name: string): string {
+    if (count === 1) {
+      let s = "";
+      s += "Hello ";
+      s += escapeHTML(name);
+      s += "! You have ";
+      s += escapeHTML(String(count));
+      s += " message.";
+      return s;
+    }
+    if (count === 0) {
+      let s = "";
+      s += "Hello ";
+      s += escapeHTML(name);
+      s += "! You have no messages.";
+      return s;
+    }
+    let s = "";
+    s += "Hello ";
+    s += escapeHTML(name);
+    s += "! You have ";
+    s += escapeHTML(String(count));
+    s += " messages.";
+    return s;
+  },
+  progress(name: string, ratio: number): string {
+    let s = "";
+    s += escapeHTML(name);
+    s += " is ";
+    s += escapeHTML(formatFloat(ratio));
+    s += " done.";
+    return s;
+  },
+  welcome(name: string): string {
+    let s = "";
+    s += "Welcome, <b>";
+    s += escapeHTML(name);
+    s += "</b>!";
+    return s;
+  },
+};
+
+const translatorEs: Translator = {
+  inboxSummary(hasUnread: boolean, name: string, unread: number): string {
+    let s = "";
+    if (hasUnread) {
+      s += "Tienes ";
+      s += escapeHTML(String(unread));
+      s += " elementos sin leer";
+    } else {
+      s += "Estás al día";
+    }
+    s += ", ";
+    s += escapeHTML(name);
+    s += ".";
+    return s;
+  },
+  myGreeting(count: number, name: string): string {
+    if (count === 1) {
+      let s = "";
+      s += "Hola ";
+      s += escapeHTML(name);
+      s += "! Tienes ";
+      s += escapeHTML(String(count));
+      s += " mensaje.";
+      return s;
+    }
+    if (count === 0) {
+      let s = "";
+      s += "Hola ";
+      s += escapeHTML(name);
+      s += "! No tienes ningún mensaje.";
+      return s;
+    }
+    let s = "";
+    s += "Hola ";
+    s += escapeHTML(name);
+    s += "! Tienes ";
+    s += escapeHTML(String(count));
+    s += " mensajes.";
+    return s;
+  },
+  progress(name: string, ratio: number): string {
+    let s = "";
+    s += escapeHTML(name);
+    s += " está al ";
+    s += escapeHTML(formatFloat(ratio));
+    s += ".";
+    return s;
+  },
+  welcome(name: string): string {
+    let s = "";
+    s += "Bienvenido, <b>";
+    s += escapeHTML(name);
+    s += "</b>!";
+    return s;
+  },
+};
+
+/** translators holds a translator per language. */
+export const translators: Record<Lang, Translator> = {
+  "en": translatorEn,
+  "es": translatorEs,
+};
+
+/** t returns the translator for lang, falling back to baseLang if lang is not available. */
+export function t(lang: string): Translator {
+  return translators[lang as Lang] ?? translators[baseLang];
+}
@@ -0,0 +1,27 @@
+package models
+
+type Gender string
+
+const (
+	Female Gender = "female"
+	Male   Gender = "male"
+)
+
+type User struct {
+	Name   string
+	Gender Gender
+}
+
+type Role int
+
+const (
+	Admin Role = iota
+	Member
+)
+
+func (r Role) String() string {
+	if r == Admin {
+		return "admin"
+	}
+	return "member"
+}
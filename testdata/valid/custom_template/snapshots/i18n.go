@@ -3,12 +3,18 @@ package translations
 
 import (
 	"bytes"
+	"container/list"
+	"context"
 	"fmt"
 	"html/template"
+	"net/http"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/kofalt/go-memoize"
-	"github.com/patrickmn/go-cache"
+	"golang.org/x/text/language"
 )
 
 // Translator is implemented by all language translators.
@@ -16,6 +22,13 @@ type Translator interface {
 	MyGreeting(count int, name string) (string, error)
 }
 
+// MessageID identifies a message.
+type MessageID string
+
+const (
+	MessageIDMyGreeting MessageID = "my_greeting"
+)
+
 // Lang represents available translated languages.
 type Lang string
 
@@ -24,33 +37,241 @@ const (
 	LangEs Lang = "es"
 )
 
+// DefaultMemoCacheSize is the default maximum number of messages in a MemoCache.
+const DefaultMemoCacheSize = 1024
+
+// MemoCache is a concurrency-safe, size-bounded LRU cache of rendered messages.
+// It may be shared by memoized translators of different languages.
+type MemoCache struct {
+	mu        sync.Mutex
+	size      int
+	ttl       time.Duration
+	now       func() time.Time
+	entries   map[string]*list.Element
+	lru       *list.List
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+type memoEntry struct {
+	key     string
+	value   string
+	expires time.Time
+}
+
+// MemoStats holds MemoCache statistics.
+type MemoStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Len       int
+}
+
+// MemoCacheOption configures a MemoCache.
+type MemoCacheOption func(*MemoCache)
+
+// WithMemoSize sets the maximum number of cached messages.
+// It panics if size is not positive, since the cache is always bounded.
+func WithMemoSize(size int) MemoCacheOption {
+	if size <= 0 {
+		panic(fmt.Sprintf("memo cache size must be positive, got %d", size))
+	}
+	return func(c *MemoCache) {
+		c.size = size
+	}
+}
+
+// WithMemoTTL sets how long rendered messages are cached. Zero means no expiration.
+func WithMemoTTL(ttl time.Duration) MemoCacheOption {
+	return func(c *MemoCache) {
+		c.ttl = ttl
+	}
+}
+
+// WithMemoClock sets the clock entries expire by. It defaults to time.Now.
+func WithMemoClock(now func() time.Time) MemoCacheOption {
+	return func(c *MemoCache) {
+		c.now = now
+	}
+}
+
+// NewMemoCache initializes a MemoCache holding up to DefaultMemoCacheSize messages without expiration.
+func NewMemoCache(opts ...MemoCacheOption) *MemoCache {
+	c := &MemoCache{
+		size:    DefaultMemoCacheSize,
+		now:     time.Now,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// Stats returns cache statistics.
+func (c *MemoCache) Stats() MemoStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return MemoStats{Hits: c.hits, Misses: c.misses, Evictions: c.evictions, Len: c.lru.Len()}
+}
+
+func (c *MemoCache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return "", false
+	}
+	entry := el.Value.(*memoEntry)
+	if c.ttl > 0 && c.now().After(entry.expires) {
+		c.lru.Remove(el)
+		delete(c.entries, key)
+		c.misses++
+		return "", false
+	}
+	c.lru.MoveToFront(el)
+	c.hits++
+	return entry.value, true
+}
+
+func (c *MemoCache) add(key, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var expires time.Time
+	if c.ttl > 0 {
+		expires = c.now().Add(c.ttl)
+	}
+	if el, ok := c.entries[key]; ok {
+		el.Value = &memoEntry{key: key, value: value, expires: expires}
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&memoEntry{key: key, value: value, expires: expires})
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoEntry).key)
+		c.evictions++
+	}
+}
+
 // MemoizedTranslator wraps a Translator with a cache.
 type MemoizedTranslator struct {
+	lang       Lang
 	translator Translator
-	cache      *memoize.Memoizer
+	cache      *MemoCache
 }
 
-// NewMemoizedTranslator initializes a memoized Translator.
+// NewMemoizedTranslator initializes a memoized Translator with its own MemoCache with default options.
 func NewMemoizedTranslator(translator Translator) *MemoizedTranslator {
-	cache := memoize.NewMemoizer(cache.NoExpiration, 1*time.Hour)
+	return NewMemoizedTranslatorWithCache("", translator, nil)
+}
+
+// NewMemoizedTranslatorWithCache initializes a memoized Translator for lang,
+// whose cache may be shared with memoized translators of other languages.
+// A new MemoCache with default options is used if cache is nil.
+func NewMemoizedTranslatorWithCache(lang Lang, translator Translator, cache *MemoCache) *MemoizedTranslator {
+	if cache == nil {
+		cache = NewMemoCache()
+	}
 	return &MemoizedTranslator{
+		lang:       lang,
 		translator: translator,
 		cache:      cache,
 	}
 }
 
+// NewMemoizedTranslators wraps all translators with a shared cache.
+// A new MemoCache with default options is used if cache is nil.
+func NewMemoizedTranslators(tt map[Lang]Translator, cache *MemoCache) map[Lang]Translator {
+	if cache == nil {
+		cache = NewMemoCache()
+	}
+	memoized := make(map[Lang]Translator, len(tt))
+	for lang, t := range tt {
+		memoized[lang] = NewMemoizedTranslatorWithCache(lang, t, cache)
+	}
+	return memoized
+}
+
 // MyGreeting checks the cache or computes the message if not already cached.
 func (m *MemoizedTranslator) MyGreeting(count int, name string) (string, error) {
-	cacheKey := fmt.Sprintf("En:MyGreeting:%v:%v:", count, name)
-
-	result, _, _ := m.cache.Memoize(cacheKey, func() (interface{}, error) {
-		return m.translator.MyGreeting(count, name)
-	})
+	cacheKey := fmt.Sprintf("%s\x00MyGreeting\x00%#v\x00%#v", m.lang, count, name)
+	if rendered, ok := m.cache.get(cacheKey); ok {
+		return rendered, nil
+	}
 
-	if err, ok := result.(error); ok {
+	rendered, err := m.translator.MyGreeting(count, name)
+	if err != nil {
 		return "", err
 	}
-	return result.(string), nil
+	m.cache.add(cacheKey, rendered)
+	return rendered, nil
+}
+
+// KeyTranslator renders message ids and arguments instead of translated text,
+// e.g. my_greeting{count=3,name=Bob}, so tests don't depend on wording.
+type KeyTranslator struct{}
+
+// NewKeyTranslator initializes a KeyTranslator.
+func NewKeyTranslator() KeyTranslator {
+	return KeyTranslator{}
+}
+
+// MyGreeting renders the message id and arguments.
+func (KeyTranslator) MyGreeting(count int, name string) (string, error) {
+	return fmt.Sprintf("my_greeting{count=%v,name=%v}", count, name), nil
+}
+
+// TranslatorCall is a Translator method call recorded by RecordingTranslator.
+type TranslatorCall struct {
+	ID MessageID
+	// Args holds arguments by variable name.
+	Args map[string]any
+}
+
+// RecordingTranslator records calls for assertions, rendering them with KeyTranslator.
+// It is safe for concurrent use.
+type RecordingTranslator struct {
+	mu    sync.Mutex
+	calls []TranslatorCall
+}
+
+// NewRecordingTranslator initializes a RecordingTranslator.
+func NewRecordingTranslator() *RecordingTranslator {
+	return &RecordingTranslator{}
+}
+
+// Calls returns the recorded calls in order.
+func (r *RecordingTranslator) Calls() []TranslatorCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.calls)
+}
+
+// Reset forgets recorded calls.
+func (r *RecordingTranslator) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+func (r *RecordingTranslator) record(id MessageID, args map[string]any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, TranslatorCall{ID: id, Args: args})
+}
+
+// MyGreeting records the call and renders the message id and arguments.
+func (r *RecordingTranslator) MyGreeting(count int, name string) (string, error) {
+	r.record(MessageIDMyGreeting, map[string]any{
+		"Count": count,
+		"Name":  name,
+	})
+	return KeyTranslator{}.MyGreeting(count, name)
 }
 
 // NewTranslators initializes all translators.
@@ -61,6 +282,255 @@ func NewTranslators() map[Lang]Translator {
 	}
 }
 
+// BaseLang is the fallback language when none is set.
+const BaseLang = LangEn
+
+var (
+	defaultTranslators = sync.OnceValue(NewTranslators)
+	// installedTranslators holds the translators set with SetTranslators, if any.
+	installedTranslators atomic.Pointer[map[Lang]Translator]
+)
+
+// SetTranslators installs the translators used by T when the context carries none, by Render
+// and by the Error method of message errors, e.g. memoized, development or test translators.
+// They default to NewTranslators, which a nil map restores.
+func SetTranslators(tt map[Lang]Translator) {
+	if tt == nil {
+		installedTranslators.Store(nil)
+		return
+	}
+	installedTranslators.Store(&tt)
+}
+
+// translators returns the translators set with SetTranslators, or NewTranslators.
+func translators() map[Lang]Translator {
+	if tt := installedTranslators.Load(); tt != nil {
+		return *tt
+	}
+	return defaultTranslators()
+}
+
+type (
+	langContextKey        struct{}
+	translatorsContextKey struct{}
+)
+
+// WithLang returns a copy of ctx carrying lang.
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, langContextKey{}, lang)
+}
+
+// LangFromContext returns the language carried by ctx, or BaseLang if none is set.
+func LangFromContext(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(langContextKey{}).(Lang); ok {
+		return lang
+	}
+	return BaseLang
+}
+
+var (
+	// matcherLangs holds available languages in Matcher order, starting with BaseLang.
+	matcherLangs = []Lang{
+		BaseLang,
+		LangEs,
+	}
+	matcherTags = []language.Tag{
+		language.MustParse("en"),
+		language.MustParse("es"),
+	}
+)
+
+// Matcher matches language preferences against available languages, defaulting to BaseLang.
+var Matcher = language.NewMatcher(matcherTags)
+
+// MatchLang returns the best available language for an Accept-Language header value.
+// It returns BaseLang with language.No confidence if nothing matches.
+func MatchLang(acceptLanguage string) (Lang, language.Confidence) {
+	tags, _, _ := language.ParseAcceptLanguage(acceptLanguage)
+	_, i, conf := Matcher.Match(tags...)
+	return matcherLangs[i], conf
+}
+
+// ParseLang returns the available language for the given BCP 47 tag, e.g. "es".
+func ParseLang(s string) (Lang, bool) {
+	tag, err := language.Parse(s)
+	if err != nil {
+		return "", false
+	}
+	for i, t := range matcherTags {
+		if t == tag {
+			return matcherLangs[i], true
+		}
+	}
+	return "", false
+}
+
+// WithTranslators returns a copy of ctx carrying the translators T uses instead of those set with SetTranslators.
+func WithTranslators(ctx context.Context, tt map[Lang]Translator) context.Context {
+	return context.WithValue(ctx, translatorsContextKey{}, tt)
+}
+
+// T returns the translator for the language carried by ctx, from the translators carried by ctx
+// or else those set with SetTranslators.
+// It falls back to BaseLang if no language is set or the language is not available.
+func T(ctx context.Context) Translator {
+	tt, ok := ctx.Value(translatorsContextKey{}).(map[Lang]Translator)
+	if !ok {
+		tt = translators()
+	}
+	if t, ok := tt[LangFromContext(ctx)]; ok {
+		return t
+	}
+	return tt[BaseLang]
+}
+
+// UnknownMessageError is returned by Render for an unknown message id.
+type UnknownMessageError struct {
+	ID MessageID
+}
+
+func (e *UnknownMessageError) Error() string {
+	return fmt.Sprintf("unknown message %q", e.ID)
+}
+
+// MissingArgumentError is returned by Render when a message argument is missing.
+type MissingArgumentError struct {
+	ID  MessageID
+	Arg string
+}
+
+func (e *MissingArgumentError) Error() string {
+	return fmt.Sprintf("message %q: missing argument %s", e.ID, e.Arg)
+}
+
+// InvalidArgumentError is returned by Render when a message argument has the wrong type.
+type InvalidArgumentError struct {
+	ID    MessageID
+	Arg   string
+	Type  string
+	Value any
+}
+
+func (e *InvalidArgumentError) Error() string {
+	return fmt.Sprintf("message %q: argument %s must be %s, got %T", e.ID, e.Arg, e.Type, e.Value)
+}
+
+// Render renders a message by id with arguments keyed by variable name.
+// It uses the translator for lang set with SetTranslators, falling back to BaseLang if lang is not available.
+func Render(lang Lang, id MessageID, args map[string]any) (string, error) {
+	tt := translators()
+	t, ok := tt[lang]
+	if !ok {
+		t = tt[BaseLang]
+	}
+	switch id {
+	case MessageIDMyGreeting:
+		argCount, err := renderArg[int](id, args, "Count")
+		if err != nil {
+			return "", err
+		}
+		argName, err := renderArg[string](id, args, "Name")
+		if err != nil {
+			return "", err
+		}
+		return t.MyGreeting(argCount, argName)
+	}
+	return "", &UnknownMessageError{ID: id}
+}
+
+func renderArg[T any](id MessageID, args map[string]any, name string) (T, error) {
+	var zero T
+	v, ok := args[name]
+	if !ok {
+		return zero, &MissingArgumentError{ID: id, Arg: name}
+	}
+	typ := reflect.TypeFor[T]()
+	if v == nil && typ.Kind() == reflect.Interface {
+		return zero, nil
+	}
+	arg, ok := v.(T)
+	if !ok {
+		return zero, &InvalidArgumentError{ID: id, Arg: name, Type: typ.String(), Value: v}
+	}
+	return arg, nil
+}
+
+// LangSource resolves a language from a request.
+type LangSource struct {
+	// Resolve returns the language of r, if any.
+	Resolve func(r *http.Request) (Lang, bool)
+	// Headers are the request headers Resolve reads, listed in the Vary response header
+	// so that shared caches don't serve a response in one language to requests resolving another.
+	Headers []string
+}
+
+// QueryLangSource resolves the language from a query parameter, e.g. ?lang=es.
+func QueryLangSource(param string) LangSource {
+	return LangSource{
+		Resolve: func(r *http.Request) (Lang, bool) {
+			return ParseLang(r.URL.Query().Get(param))
+		},
+	}
+}
+
+// CookieLangSource resolves the language from a cookie.
+func CookieLangSource(name string) LangSource {
+	return LangSource{
+		Resolve: func(r *http.Request) (Lang, bool) {
+			c, err := r.Cookie(name)
+			if err != nil {
+				return "", false
+			}
+			return ParseLang(c.Value)
+		},
+		Headers: []string{"Cookie"},
+	}
+}
+
+// AcceptLanguageSource resolves the language from the Accept-Language header.
+func AcceptLanguageSource() LangSource {
+	return LangSource{
+		Resolve: func(r *http.Request) (Lang, bool) {
+			lang, conf := MatchLang(r.Header.Get("Accept-Language"))
+			return lang, conf != language.No
+		},
+		Headers: []string{"Accept-Language"},
+	}
+}
+
+// LangMiddleware stores the language resolved by the first matching source in the request context,
+// retrievable with LangFromContext and T, and sets the Content-Language response header.
+// BaseLang is used if no source matches.
+// The Vary response header lists the headers read by the sources tried.
+// Sources default to QueryLangSource("lang"), CookieLangSource("lang") and AcceptLanguageSource().
+func LangMiddleware(sources ...LangSource) func(http.Handler) http.Handler {
+	if len(sources) == 0 {
+		sources = []LangSource{QueryLangSource("lang"), CookieLangSource("lang"), AcceptLanguageSource()}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lang := BaseLang
+			var vary []string
+			for _, source := range sources {
+				for _, h := range source.Headers {
+					if !slices.Contains(vary, h) {
+						vary = append(vary, h)
+					}
+				}
+				if l, ok := source.Resolve(r); ok {
+					lang = l
+					break
+				}
+			}
+			for _, h := range vary {
+				w.Header().Add("Vary", h)
+			}
+			w.Header().Set("Content-Language", string(lang))
+			next.ServeHTTP(w, r.WithContext(WithLang(r.Context(), lang)))
+		})
+	}
+}
+
 type en struct {
 	MyGreetingDft     *template.Template
 	MyGreetingCustom0 *template.Template
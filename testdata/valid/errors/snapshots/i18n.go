@@ -0,0 +1,377 @@
+// Code generated by i18ngo. DO NOT EDIT.
+package translations
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"html"
+	"html/template"
+	"sync"
+	"sync/atomic"
+)
+
+// Translator is implemented by all language translators.
+type Translator interface {
+	InvalidName(name string) (string, error)
+	MyGreeting(name string) (string, error)
+	NotFound(id int, resource string) (string, error)
+	QuotaExceededError(limit int) (string, error)
+}
+
+// MessageID identifies a message.
+type MessageID string
+
+const (
+	MessageIDInvalidName        MessageID = "invalid_name"
+	MessageIDMyGreeting         MessageID = "my_greeting"
+	MessageIDNotFound           MessageID = "not_found"
+	MessageIDQuotaExceededError MessageID = "quota_exceeded_error"
+)
+
+// Lang represents available translated languages.
+type Lang string
+
+const (
+	LangEn Lang = "en"
+	LangEs Lang = "es"
+)
+
+// NewTranslators initializes all translators.
+func NewTranslators() map[Lang]Translator {
+	return map[Lang]Translator{
+		LangEn: newEn(),
+		LangEs: newEs(),
+	}
+}
+
+// BaseLang is the fallback language when none is set.
+const BaseLang = LangEn
+
+var (
+	defaultTranslators = sync.OnceValue(NewTranslators)
+	// installedTranslators holds the translators set with SetTranslators, if any.
+	installedTranslators atomic.Pointer[map[Lang]Translator]
+)
+
+// SetTranslators installs the translators used by T when the context carries none, by Render
+// and by the Error method of message errors, e.g. memoized, development or test translators.
+// They default to NewTranslators, which a nil map restores.
+func SetTranslators(tt map[Lang]Translator) {
+	if tt == nil {
+		installedTranslators.Store(nil)
+		return
+	}
+	installedTranslators.Store(&tt)
+}
+
+// translators returns the translators set with SetTranslators, or NewTranslators.
+func translators() map[Lang]Translator {
+	if tt := installedTranslators.Load(); tt != nil {
+		return *tt
+	}
+	return defaultTranslators()
+}
+
+type (
+	langContextKey        struct{}
+	translatorsContextKey struct{}
+)
+
+// WithLang returns a copy of ctx carrying lang.
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, langContextKey{}, lang)
+}
+
+// LangFromContext returns the language carried by ctx, or BaseLang if none is set.
+func LangFromContext(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(langContextKey{}).(Lang); ok {
+		return lang
+	}
+	return BaseLang
+}
+
+// WithTranslators returns a copy of ctx carrying the translators T uses instead of those set with SetTranslators.
+func WithTranslators(ctx context.Context, tt map[Lang]Translator) context.Context {
+	return context.WithValue(ctx, translatorsContextKey{}, tt)
+}
+
+// T returns the translator for the language carried by ctx, from the translators carried by ctx
+// or else those set with SetTranslators.
+// It falls back to BaseLang if no language is set or the language is not available.
+func T(ctx context.Context) Translator {
+	tt, ok := ctx.Value(translatorsContextKey{}).(map[Lang]Translator)
+	if !ok {
+		tt = translators()
+	}
+	if t, ok := tt[LangFromContext(ctx)]; ok {
+		return t
+	}
+	return tt[BaseLang]
+}
+
+// ErrInvalidName is matched by InvalidNameError through errors.Is.
+var ErrInvalidName = errors.New("invalid_name")
+
+// InvalidNameError is a localizable error for the invalid_name message.
+type InvalidNameError struct {
+	Name string
+}
+
+// Error renders the message in BaseLang with the translators set with SetTranslators as plain text,
+// unescaping the HTML-escaped values.
+func (e *InvalidNameError) Error() string {
+	return html.UnescapeString(e.Localize(translators()[BaseLang]))
+}
+
+// Is reports whether target is ErrInvalidName.
+func (e *InvalidNameError) Is(target error) bool {
+	return target == ErrInvalidName
+}
+
+// Localize renders the message with the given translator.
+// It falls back to the message id if rendering fails.
+func (e *InvalidNameError) Localize(t Translator) string {
+	s, err := t.InvalidName(e.Name)
+	if err != nil {
+		return string(MessageIDInvalidName)
+	}
+	return s
+}
+
+// ErrNotFound is matched by NotFoundError through errors.Is.
+var ErrNotFound = errors.New("not_found")
+
+// NotFoundError is a localizable error for the not_found message.
+type NotFoundError struct {
+	ID       int
+	Resource string
+}
+
+// Error renders the message in BaseLang with the translators set with SetTranslators as plain text,
+// unescaping the HTML-escaped values.
+func (e *NotFoundError) Error() string {
+	return html.UnescapeString(e.Localize(translators()[BaseLang]))
+}
+
+// Is reports whether target is ErrNotFound.
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// Localize renders the message with the given translator.
+// It falls back to the message id if rendering fails.
+func (e *NotFoundError) Localize(t Translator) string {
+	s, err := t.NotFound(e.ID, e.Resource)
+	if err != nil {
+		return string(MessageIDNotFound)
+	}
+	return s
+}
+
+// ErrQuotaExceeded is matched by QuotaExceededError through errors.Is.
+var ErrQuotaExceeded = errors.New("quota_exceeded_error")
+
+// QuotaExceededError is a localizable error for the quota_exceeded_error message.
+type QuotaExceededError struct {
+	Limit int
+}
+
+// Error renders the message in BaseLang with the translators set with SetTranslators as plain text,
+// unescaping the HTML-escaped values.
+func (e *QuotaExceededError) Error() string {
+	return html.UnescapeString(e.Localize(translators()[BaseLang]))
+}
+
+// Is reports whether target is ErrQuotaExceeded.
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// Localize renders the message with the given translator.
+// It falls back to the message id if rendering fails.
+func (e *QuotaExceededError) Localize(t Translator) string {
+	s, err := t.QuotaExceededError(e.Limit)
+	if err != nil {
+		return string(MessageIDQuotaExceededError)
+	}
+	return s
+}
+
+type en struct {
+	InvalidNameDft            *template.Template
+	MyGreetingDft             *template.Template
+	NotFoundDft               *template.Template
+	QuotaExceededErrorDft     *template.Template
+	QuotaExceededErrorCustom0 *template.Template
+}
+
+func newEn() *en {
+	return &en{
+		InvalidNameDft:            template.Must(template.New("InvalidName").Parse("{{ .Name }} isn't a valid name.")),
+		MyGreetingDft:             template.Must(template.New("MyGreeting").Parse("Hello {{ .Name }}!")),
+		NotFoundDft:               template.Must(template.New("NotFound").Parse("{{ .Resource }} {{ .ID }} was not found.")),
+		QuotaExceededErrorDft:     template.Must(template.New("QuotaExceededError").Parse("You have used all of your {{ .Limit }} requests.")),
+		QuotaExceededErrorCustom0: template.Must(template.New("QuotaExceededErrorCustom0").Parse("You have used your only request.")),
+	}
+}
+
+// InvalidName renders a properly translated message.
+func (t *en) InvalidName(name string) (string, error) {
+	data := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	var tmpl *template.Template
+	tmpl = t.InvalidNameDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// MyGreeting renders a properly translated message.
+func (t *en) MyGreeting(name string) (string, error) {
+	data := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	var tmpl *template.Template
+	tmpl = t.MyGreetingDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// NotFound renders a properly translated message.
+func (t *en) NotFound(id int, resource string) (string, error) {
+	data := struct {
+		ID       int
+		Resource string
+	}{
+		ID:       id,
+		Resource: resource,
+	}
+	var tmpl *template.Template
+	tmpl = t.NotFoundDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// QuotaExceededError renders a properly translated message.
+func (t *en) QuotaExceededError(limit int) (string, error) {
+	data := struct {
+		Limit int
+	}{
+		Limit: limit,
+	}
+	var tmpl *template.Template
+	switch {
+	case limit == 1:
+		tmpl = t.QuotaExceededErrorCustom0
+	default:
+		tmpl = t.QuotaExceededErrorDft
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+type es struct {
+	InvalidNameDft            *template.Template
+	MyGreetingDft             *template.Template
+	NotFoundDft               *template.Template
+	QuotaExceededErrorDft     *template.Template
+	QuotaExceededErrorCustom0 *template.Template
+}
+
+func newEs() *es {
+	return &es{
+		InvalidNameDft:            template.Must(template.New("InvalidName").Parse("{{ .Name }} no es un nombre válido.")),
+		MyGreetingDft:             template.Must(template.New("MyGreeting").Parse("¡Hola {{ .Name }}!")),
+		NotFoundDft:               template.Must(template.New("NotFound").Parse("No se ha encontrado {{ .Resource }} {{ .ID }}.")),
+		QuotaExceededErrorDft:     template.Must(template.New("QuotaExceededError").Parse("Has usado tus {{ .Limit }} peticiones.")),
+		QuotaExceededErrorCustom0: template.Must(template.New("QuotaExceededErrorCustom0").Parse("Has usado tu única petición.")),
+	}
+}
+
+// InvalidName renders a properly translated message.
+func (t *es) InvalidName(name string) (string, error) {
+	data := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	var tmpl *template.Template
+	tmpl = t.InvalidNameDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// MyGreeting renders a properly translated message.
+func (t *es) MyGreeting(name string) (string, error) {
+	data := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	var tmpl *template.Template
+	tmpl = t.MyGreetingDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// NotFound renders a properly translated message.
+func (t *es) NotFound(id int, resource string) (string, error) {
+	data := struct {
+		ID       int
+		Resource string
+	}{
+		ID:       id,
+		Resource: resource,
+	}
+	var tmpl *template.Template
+	tmpl = t.NotFoundDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// QuotaExceededError renders a properly translated message.
+func (t *es) QuotaExceededError(limit int) (string, error) {
+	data := struct {
+		Limit int
+	}{
+		Limit: limit,
+	}
+	var tmpl *template.Template
+	switch {
+	case limit == 1:
+		tmpl = t.QuotaExceededErrorCustom0
+	default:
+		tmpl = t.QuotaExceededErrorDft
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,428 @@
+// Code generated by i18ngo. DO NOT EDIT.
+package translations
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/danicc097/i18ngo/testdata/valid/custom_imports/models"
+)
+
+// Translator is implemented by all language translators.
+type Translator interface {
+	Discount(member bool, percent float32) (string, error)
+	Downloads(total uint64) (string, error)
+	MyGreeting(count int, name string) (string, error)
+	RoleBadge(name string, role models.Role) (string, error)
+	Salutation(gender models.Gender, name string) (string, error)
+	Title(name string) (string, error)
+}
+
+// MessageID identifies a message.
+type MessageID string
+
+const (
+	MessageIDDiscount   MessageID = "discount"
+	MessageIDDownloads  MessageID = "downloads"
+	MessageIDMyGreeting MessageID = "my_greeting"
+	MessageIDRoleBadge  MessageID = "role_badge"
+	MessageIDSalutation MessageID = "salutation"
+	MessageIDTitle      MessageID = "title"
+)
+
+// Lang represents available translated languages.
+type Lang string
+
+const (
+	LangEn Lang = "en"
+	LangEs Lang = "es"
+)
+
+// NewTranslators initializes all translators.
+func NewTranslators() map[Lang]Translator {
+	return map[Lang]Translator{
+		LangEn: newEn(),
+		LangEs: newEs(),
+	}
+}
+
+// BaseLang is the fallback language when none is set.
+const BaseLang = LangEn
+
+var (
+	defaultTranslators = sync.OnceValue(NewTranslators)
+	// installedTranslators holds the translators set with SetTranslators, if any.
+	installedTranslators atomic.Pointer[map[Lang]Translator]
+)
+
+// SetTranslators installs the translators used by T when the context carries none, by Render
+// and by the Error method of message errors, e.g. memoized, development or test translators.
+// They default to NewTranslators, which a nil map restores.
+func SetTranslators(tt map[Lang]Translator) {
+	if tt == nil {
+		installedTranslators.Store(nil)
+		return
+	}
+	installedTranslators.Store(&tt)
+}
+
+// translators returns the translators set with SetTranslators, or NewTranslators.
+func translators() map[Lang]Translator {
+	if tt := installedTranslators.Load(); tt != nil {
+		return *tt
+	}
+	return defaultTranslators()
+}
+
+type (
+	langContextKey        struct{}
+	translatorsContextKey struct{}
+)
+
+// WithLang returns a copy of ctx carrying lang.
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, langContextKey{}, lang)
+}
+
+// LangFromContext returns the language carried by ctx, or BaseLang if none is set.
+func LangFromContext(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(langContextKey{}).(Lang); ok {
+		return lang
+	}
+	return BaseLang
+}
+
+// WithTranslators returns a copy of ctx carrying the translators T uses instead of those set with SetTranslators.
+func WithTranslators(ctx context.Context, tt map[Lang]Translator) context.Context {
+	return context.WithValue(ctx, translatorsContextKey{}, tt)
+}
+
+// T returns the translator for the language carried by ctx, from the translators carried by ctx
+// or else those set with SetTranslators.
+// It falls back to BaseLang if no language is set or the language is not available.
+func T(ctx context.Context) Translator {
+	tt, ok := ctx.Value(translatorsContextKey{}).(map[Lang]Translator)
+	if !ok {
+		tt = translators()
+	}
+	if t, ok := tt[LangFromContext(ctx)]; ok {
+		return t
+	}
+	return tt[BaseLang]
+}
+
+type en struct {
+	DiscountDft       *template.Template
+	DownloadsDft      *template.Template
+	MyGreetingDft     *template.Template
+	MyGreetingCustom0 *template.Template
+	MyGreetingCustom1 *template.Template
+	RoleBadgeDft      *template.Template
+	RoleBadgeCustom0  *template.Template
+	SalutationDft     *template.Template
+	SalutationCustom0 *template.Template
+	TitleDft          *template.Template
+	TitleCustom0      *template.Template
+}
+
+func newEn() *en {
+	return &en{
+		DiscountDft:       template.Must(template.New("Discount").Parse("{{ if .Member }}Members save {{ .Percent }}%.{{ else }}Join to save.{{ end }}")),
+		DownloadsDft:      template.Must(template.New("Downloads").Parse("Downloaded {{ .Total }} times.")),
+		MyGreetingDft:     template.Must(template.New("MyGreeting").Parse("Hello {{ .Name }}! You have {{ .Count }} messages.")),
+		MyGreetingCustom0: template.Must(template.New("MyGreetingCustom0").Parse("Hello {{ .Name }}! You have {{ .Count }} message.")),
+		MyGreetingCustom1: template.Must(template.New("MyGreetingCustom1").Parse("Hello {{ .Name }}! You have no messages.")),
+		RoleBadgeDft:      template.Must(template.New("RoleBadge").Parse("{{ .Name }} ({{ .Role }})")),
+		RoleBadgeCustom0:  template.Must(template.New("RoleBadgeCustom0").Parse("{{ .Name }}, PhD ({{ .Role }})")),
+		SalutationDft:     template.Must(template.New("Salutation").Parse("Dear {{ .Name }},")),
+		SalutationCustom0: template.Must(template.New("SalutationCustom0").Parse("Dear Ms. {{ .Name }},")),
+		TitleDft:          template.Must(template.New("Title").Parse("{{ .Name }}")),
+		TitleCustom0:      template.Must(template.New("TitleCustom0").Parse("{{ .Name }}, PhD")),
+	}
+}
+
+// Discount renders a properly translated message.
+func (t *en) Discount(member bool, percent float32) (string, error) {
+	data := struct {
+		Member  bool
+		Percent float32
+	}{
+		Member:  member,
+		Percent: percent,
+	}
+	var tmpl *template.Template
+	tmpl = t.DiscountDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Downloads renders a properly translated message.
+func (t *en) Downloads(total uint64) (string, error) {
+	data := struct {
+		Total uint64
+	}{
+		Total: total,
+	}
+	var tmpl *template.Template
+	tmpl = t.DownloadsDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// MyGreeting renders a properly translated message.
+func (t *en) MyGreeting(count int, name string) (string, error) {
+	data := struct {
+		Count int
+		Name  string
+	}{
+		Count: count,
+		Name:  name,
+	}
+	var tmpl *template.Template
+	switch {
+	case count == 1:
+		tmpl = t.MyGreetingCustom0
+	case count <= 0:
+		tmpl = t.MyGreetingCustom1
+	default:
+		tmpl = t.MyGreetingDft
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RoleBadge renders a properly translated message.
+func (t *en) RoleBadge(name string, role models.Role) (string, error) {
+	data := struct {
+		Name string
+		Role models.Role
+	}{
+		Name: name,
+		Role: role,
+	}
+	var tmpl *template.Template
+	switch {
+	case strings.HasPrefix(name, "Dr"):
+		tmpl = t.RoleBadgeCustom0
+	default:
+		tmpl = t.RoleBadgeDft
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Salutation renders a properly translated message.
+func (t *en) Salutation(gender models.Gender, name string) (string, error) {
+	data := struct {
+		Gender models.Gender
+		Name   string
+	}{
+		Gender: gender,
+		Name:   name,
+	}
+	var tmpl *template.Template
+	switch {
+	case gender == models.Female:
+		tmpl = t.SalutationCustom0
+	default:
+		tmpl = t.SalutationDft
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Title renders a properly translated message.
+func (t *en) Title(name string) (string, error) {
+	data := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	var tmpl *template.Template
+	switch {
+	case strings.HasPrefix(name, "Dr"):
+		tmpl = t.TitleCustom0
+	default:
+		tmpl = t.TitleDft
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+type es struct {
+	DiscountDft       *template.Template
+	DownloadsDft      *template.Template
+	MyGreetingDft     *template.Template
+	MyGreetingCustom0 *template.Template
+	MyGreetingCustom1 *template.Template
+	RoleBadgeDft      *template.Template
+	RoleBadgeCustom0  *template.Template
+	SalutationDft     *template.Template
+	SalutationCustom0 *template.Template
+	TitleDft          *template.Template
+	TitleCustom0      *template.Template
+}
+
+func newEs() *es {
+	return &es{
+		DiscountDft:       template.Must(template.New("Discount").Parse("{{ if .Member }}Los socios ahorran un {{ .Percent }}%.{{ else }}Únete para ahorrar.{{ end }}")),
+		DownloadsDft:      template.Must(template.New("Downloads").Parse("Descargado {{ .Total }} veces.")),
+		MyGreetingDft:     template.Must(template.New("MyGreeting").Parse("Hola {{ .Name }}! Tienes {{ .Count }} mensajes.")),
+		MyGreetingCustom0: template.Must(template.New("MyGreetingCustom0").Parse("Hola {{ .Name }}! Tienes {{ .Count }} mensaje.")),
+		MyGreetingCustom1: template.Must(template.New("MyGreetingCustom1").Parse("Hola {{ .Name }}! No tienes ningún mensaje.")),
+		RoleBadgeDft:      template.Must(template.New("RoleBadge").Parse("{{ .Name }} ({{ .Role }})")),
+		RoleBadgeCustom0:  template.Must(template.New("RoleBadgeCustom0").Parse("{{ .Name }}, PhD ({{ .Role }})")),
+		SalutationDft:     template.Must(template.New("Salutation").Parse("Estimado/a {{ .Name }}:")),
+		SalutationCustom0: template.Must(template.New("SalutationCustom0").Parse("Estimada {{ .Name }}:")),
+		TitleDft:          template.Must(template.New("Title").Parse("{{ .Name }}")),
+		TitleCustom0:      template.Must(template.New("TitleCustom0").Parse("{{ .Name }}, PhD")),
+	}
+}
+
+// Discount renders a properly translated message.
+func (t *es) Discount(member bool, percent float32) (string, error) {
+	data := struct {
+		Member  bool
+		Percent float32
+	}{
+		Member:  member,
+		Percent: percent,
+	}
+	var tmpl *template.Template
+	tmpl = t.DiscountDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Downloads renders a properly translated message.
+func (t *es) Downloads(total uint64) (string, error) {
+	data := struct {
+		Total uint64
+	}{
+		Total: total,
+	}
+	var tmpl *template.Template
+	tmpl = t.DownloadsDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// MyGreeting renders a properly translated message.
+func (t *es) MyGreeting(count int, name string) (string, error) {
+	data := struct {
+		Count int
+		Name  string
+	}{
+		Count: count,
+		Name:  name,
+	}
+	var tmpl *template.Template
+	switch {
+	case count == 1:
+		tmpl = t.MyGreetingCustom0
+	case count <= 0:
+		tmpl = t.MyGreetingCustom1
+	default:
+		tmpl = t.MyGreetingDft
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RoleBadge renders a properly translated message.
+func (t *es) RoleBadge(name string, role models.Role) (string, error) {
+	data := struct {
+		Name string
+		Role models.Role
+	}{
+		Name: name,
+		Role: role,
+	}
+	var tmpl *template.Template
+	switch {
+	case strings.HasPrefix(name, "Dr"):
+		tmpl = t.RoleBadgeCustom0
+	default:
+		tmpl = t.RoleBadgeDft
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Salutation renders a properly translated message.
+func (t *es) Salutation(gender models.Gender, name string) (string, error) {
+	data := struct {
+		Gender models.Gender
+		Name   string
+	}{
+		Gender: gender,
+		Name:   name,
+	}
+	var tmpl *template.Template
+	switch {
+	case gender == models.Female:
+		tmpl = t.SalutationCustom0
+	default:
+		tmpl = t.SalutationDft
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Title renders a properly translated message.
+func (t *es) Title(name string) (string, error) {
+	data := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	var tmpl *template.Template
+	switch {
+	case strings.HasPrefix(name, "Dr"):
+		tmpl = t.TitleCustom0
+	default:
+		tmpl = t.TitleDft
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
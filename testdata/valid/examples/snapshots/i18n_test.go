@@ -0,0 +1,98 @@
+// Code generated by i18ngo. DO NOT EDIT.
+package translations
+
+import (
+	"testing"
+
+	"github.com/danicc097/i18ngo/testdata/valid/custom_imports/models"
+)
+
+func TestExamples(t *testing.T) {
+	t.Parallel()
+
+	tt := NewTranslators()
+	tests := []struct {
+		name   string
+		lang   Lang
+		render func(Translator) (string, error)
+		want   string
+	}{
+		{
+			name:   "en/discount/0",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.Discount(true, float32(12.5)) },
+			want:   "Members save 12.5%.",
+		},
+		{
+			name:   "en/discount/1",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.Discount(false, float32(10)) },
+			want:   "Join to save.",
+		},
+		{
+			name:   "en/downloads/0",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.Downloads(uint64(18446744073709551615)) },
+			want:   "Downloaded 18446744073709551615 times.",
+		},
+		{
+			name:   "en/my_greeting/0",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.MyGreeting(0, "Bob") },
+			want:   "Hello Bob! You have no messages.",
+		},
+		{
+			name:   "en/my_greeting/1",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.MyGreeting(1, "Bob") },
+			want:   "Hello Bob! You have 1 message.",
+		},
+		{
+			name:   "en/my_greeting/2",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.MyGreeting(3, "<b>Bob</b>") },
+			want:   "Hello &lt;b&gt;Bob&lt;/b&gt;! You have 3 messages.",
+		},
+		{
+			name:   "en/role_badge/0",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.RoleBadge("Ann", models.Role(0)) },
+			want:   "Ann (admin)",
+		},
+		{
+			name:   "en/salutation/0",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.Salutation(models.Gender("female"), "Ann") },
+			want:   "Dear Ms. Ann,",
+		},
+		{
+			name:   "en/title/0",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.Title("Dr Ann") },
+			want:   "Dr Ann, PhD",
+		},
+		{
+			name:   "es/my_greeting/0",
+			lang:   LangEs,
+			render: func(tr Translator) (string, error) { return tr.MyGreeting(-1, "Ana") },
+			want:   "Hola Ana! No tienes ningún mensaje.",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tr, ok := tt[tc.lang]
+			if !ok {
+				t.Skipf("%s is not compiled in", tc.lang)
+			}
+			got, err := tc.render(tr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
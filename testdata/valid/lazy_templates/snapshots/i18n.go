@@ -0,0 +1,405 @@
+// Code generated by i18ngo. DO NOT EDIT.
+package translations
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"sync"
+	"sync/atomic"
+)
+
+// Translator is implemented by all language translators.
+type Translator interface {
+	InboxSummary(hasUnread bool, name string, unread int) (string, error)
+	MyGreeting(count int, name string) (string, error)
+	Progress(name string, ratio float64) (string, error)
+	Welcome(name string) (string, error)
+}
+
+// MessageID identifies a message.
+type MessageID string
+
+const (
+	MessageIDInboxSummary MessageID = "inbox_summary"
+	MessageIDMyGreeting   MessageID = "my_greeting"
+	MessageIDProgress     MessageID = "progress"
+	MessageIDWelcome      MessageID = "welcome"
+)
+
+// Lang represents available translated languages.
+type Lang string
+
+const (
+	LangEn Lang = "en"
+	LangEs Lang = "es"
+)
+
+// NewTranslators initializes all translators.
+func NewTranslators() map[Lang]Translator {
+	return map[Lang]Translator{
+		LangEn: newEn(),
+		LangEs: newEs(),
+	}
+}
+
+// BaseLang is the fallback language when none is set.
+const BaseLang = LangEn
+
+var (
+	defaultTranslators = sync.OnceValue(NewTranslators)
+	// installedTranslators holds the translators set with SetTranslators, if any.
+	installedTranslators atomic.Pointer[map[Lang]Translator]
+)
+
+// SetTranslators installs the translators used by T when the context carries none, by Render
+// and by the Error method of message errors, e.g. memoized, development or test translators.
+// They default to NewTranslators, which a nil map restores.
+func SetTranslators(tt map[Lang]Translator) {
+	if tt == nil {
+		installedTranslators.Store(nil)
+		return
+	}
+	installedTranslators.Store(&tt)
+}
+
+// translators returns the translators set with SetTranslators, or NewTranslators.
+func translators() map[Lang]Translator {
+	if tt := installedTranslators.Load(); tt != nil {
+		return *tt
+	}
+	return defaultTranslators()
+}
+
+type (
+	langContextKey        struct{}
+	translatorsContextKey struct{}
+)
+
+// WithLang returns a copy of ctx carrying lang.
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, langContextKey{}, lang)
+}
+
+// LangFromContext returns the language carried by ctx, or BaseLang if none is set.
+func LangFromContext(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(langContextKey{}).(Lang); ok {
+		return lang
+	}
+	return BaseLang
+}
+
+// WithTranslators returns a copy of ctx carrying the translators T uses instead of those set with SetTranslators.
+func WithTranslators(ctx context.Context, tt map[Lang]Translator) context.Context {
+	return context.WithValue(ctx, translatorsContextKey{}, tt)
+}
+
+// T returns the translator for the language carried by ctx, from the translators carried by ctx
+// or else those set with SetTranslators.
+// It falls back to BaseLang if no language is set or the language is not available.
+func T(ctx context.Context) Translator {
+	tt, ok := ctx.Value(translatorsContextKey{}).(map[Lang]Translator)
+	if !ok {
+		tt = translators()
+	}
+	if t, ok := tt[LangFromContext(ctx)]; ok {
+		return t
+	}
+	return tt[BaseLang]
+}
+
+// lazyTemplate returns a function parsing a template on first use.
+func lazyTemplate(name, text string) func() (*template.Template, error) {
+	return sync.OnceValues(func() (*template.Template, error) {
+		return template.New(name).Parse(text)
+	})
+}
+
+// NewTranslatorsE initializes all translators after parsing all templates,
+// returning an error for any invalid template instead of failing on first use.
+func NewTranslatorsE() (map[Lang]Translator, error) {
+	if err := Preload(); err != nil {
+		return nil, err
+	}
+	return NewTranslators(), nil
+}
+
+// Preload parses all templates of all languages.
+func Preload() error {
+	if err := loadEnTemplates().preload(); err != nil {
+		return fmt.Errorf("en: %w", err)
+	}
+	if err := loadEsTemplates().preload(); err != nil {
+		return fmt.Errorf("es: %w", err)
+	}
+	return nil
+}
+
+type en struct{}
+
+func newEn() *en {
+	return &en{}
+}
+
+// enTemplates holds en templates, each parsed on first use.
+type enTemplates struct {
+	InboxSummaryDft   func() (*template.Template, error)
+	MyGreetingDft     func() (*template.Template, error)
+	MyGreetingCustom0 func() (*template.Template, error)
+	MyGreetingCustom1 func() (*template.Template, error)
+	ProgressDft       func() (*template.Template, error)
+	WelcomeDft        func() (*template.Template, error)
+}
+
+// loadEnTemplates initializes en templates on first use of the language.
+var loadEnTemplates = sync.OnceValue(func() *enTemplates {
+	return &enTemplates{
+		InboxSummaryDft:   lazyTemplate("InboxSummary", "{{ if .HasUnread }}You have {{ printf \"%d\" .Unread }} unread items{{ else }}You are all caught up{{ end }}, {{ .Name }}."),
+		MyGreetingDft:     lazyTemplate("MyGreeting", "Hello {{ .Name }}! You have {{ .Count }} messages."),
+		MyGreetingCustom0: lazyTemplate("MyGreetingCustom0", "Hello {{ .Name }}! You have {{ .Count }} message."),
+		MyGreetingCustom1: lazyTemplate("MyGreetingCustom1", "Hello {{ .Name }}! You have no messages."),
+		ProgressDft:       lazyTemplate("Progress", "{{ .Name }} is {{ .Ratio }} done."),
+		WelcomeDft:        lazyTemplate("Welcome", "Welcome, <b>{{ .Name }}</b>!"),
+	}
+})
+
+func (t *enTemplates) preload() error {
+	for _, load := range []func() (*template.Template, error){
+		t.InboxSummaryDft,
+		t.MyGreetingDft,
+		t.MyGreetingCustom0,
+		t.MyGreetingCustom1,
+		t.ProgressDft,
+		t.WelcomeDft,
+	} {
+		if _, err := load(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InboxSummary renders a properly translated message.
+func (t *en) InboxSummary(hasUnread bool, name string, unread int) (string, error) {
+	data := struct {
+		HasUnread bool
+		Name      string
+		Unread    int
+	}{
+		HasUnread: hasUnread,
+		Name:      name,
+		Unread:    unread,
+	}
+	tmpl, err := loadEnTemplates().InboxSummaryDft()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// MyGreeting renders a properly translated message.
+func (t *en) MyGreeting(count int, name string) (string, error) {
+	data := struct {
+		Count int
+		Name  string
+	}{
+		Count: count,
+		Name:  name,
+	}
+	tmpls := loadEnTemplates()
+	var load func() (*template.Template, error)
+	switch {
+	case count == 1:
+		load = tmpls.MyGreetingCustom0
+	case count == 0:
+		load = tmpls.MyGreetingCustom1
+	default:
+		load = tmpls.MyGreetingDft
+	}
+	tmpl, err := load()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Progress renders a properly translated message.
+func (t *en) Progress(name string, ratio float64) (string, error) {
+	data := struct {
+		Name  string
+		Ratio float64
+	}{
+		Name:  name,
+		Ratio: ratio,
+	}
+	tmpl, err := loadEnTemplates().ProgressDft()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Welcome renders a properly translated message.
+func (t *en) Welcome(name string) (string, error) {
+	data := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	tmpl, err := loadEnTemplates().WelcomeDft()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+type es struct{}
+
+func newEs() *es {
+	return &es{}
+}
+
+// esTemplates holds es templates, each parsed on first use.
+type esTemplates struct {
+	InboxSummaryDft   func() (*template.Template, error)
+	MyGreetingDft     func() (*template.Template, error)
+	MyGreetingCustom0 func() (*template.Template, error)
+	MyGreetingCustom1 func() (*template.Template, error)
+	ProgressDft       func() (*template.Template, error)
+	WelcomeDft        func() (*template.Template, error)
+}
+
+// loadEsTemplates initializes es templates on first use of the language.
+var loadEsTemplates = sync.OnceValue(func() *esTemplates {
+	return &esTemplates{
+		InboxSummaryDft:   lazyTemplate("InboxSummary", "{{ if .HasUnread }}Tienes {{ printf \"%d\" .Unread }} elementos sin leer{{ else }}Estás al día{{ end }}, {{ .Name }}."),
+		MyGreetingDft:     lazyTemplate("MyGreeting", "Hola {{ .Name }}! Tienes {{ .Count }} mensajes."),
+		MyGreetingCustom0: lazyTemplate("MyGreetingCustom0", "Hola {{ .Name }}! Tienes {{ .Count }} mensaje."),
+		MyGreetingCustom1: lazyTemplate("MyGreetingCustom1", "Hola {{ .Name }}! No tienes ningún mensaje."),
+		ProgressDft:       lazyTemplate("Progress", "{{ .Name }} está al {{ .Ratio }}."),
+		WelcomeDft:        lazyTemplate("Welcome", "Bienvenido, <b>{{ .Name }}</b>!"),
+	}
+})
+
+func (t *esTemplates) preload() error {
+	for _, load := range []func() (*template.Template, error){
+		t.InboxSummaryDft,
+		t.MyGreetingDft,
+		t.MyGreetingCustom0,
+		t.MyGreetingCustom1,
+		t.ProgressDft,
+		t.WelcomeDft,
+	} {
+		if _, err := load(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InboxSummary renders a properly translated message.
+func (t *es) InboxSummary(hasUnread bool, name string, unread int) (string, error) {
+	data := struct {
+		HasUnread bool
+		Name      string
+		Unread    int
+	}{
+		HasUnread: hasUnread,
+		Name:      name,
+		Unread:    unread,
+	}
+	tmpl, err := loadEsTemplates().InboxSummaryDft()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// MyGreeting renders a properly translated message.
+func (t *es) MyGreeting(count int, name string) (string, error) {
+	data := struct {
+		Count int
+		Name  string
+	}{
+		Count: count,
+		Name:  name,
+	}
+	tmpls := loadEsTemplates()
+	var load func() (*template.Template, error)
+	switch {
+	case count == 1:
+		load = tmpls.MyGreetingCustom0
+	case count == 0:
+		load = tmpls.MyGreetingCustom1
+	default:
+		load = tmpls.MyGreetingDft
+	}
+	tmpl, err := load()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Progress renders a properly translated message.
+func (t *es) Progress(name string, ratio float64) (string, error) {
+	data := struct {
+		Name  string
+		Ratio float64
+	}{
+		Name:  name,
+		Ratio: ratio,
+	}
+	tmpl, err := loadEsTemplates().ProgressDft()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Welcome renders a properly translated message.
+func (t *es) Welcome(name string) (string, error) {
+	data := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	tmpl, err := loadEsTemplates().WelcomeDft()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
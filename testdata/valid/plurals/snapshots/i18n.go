@@ -0,0 +1,475 @@
+// Code generated by i18ngo. DO NOT EDIT.
+package translations
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+
+	"github.com/danicc097/i18ngo/i18ndev"
+)
+
+// Translator is implemented by all language translators.
+type Translator interface {
+	FilesDeleted(files uint, folder string) (string, error)
+	LeaderboardRank(name string, rank int) (string, error)
+	UnreadMessages(count int) (string, error)
+}
+
+// MessageID identifies a message.
+type MessageID string
+
+const (
+	MessageIDFilesDeleted    MessageID = "files_deleted"
+	MessageIDLeaderboardRank MessageID = "leaderboard_rank"
+	MessageIDUnreadMessages  MessageID = "unread_messages"
+)
+
+// Lang represents available translated languages.
+type Lang string
+
+const (
+	LangEn Lang = "en"
+	LangPl Lang = "pl"
+)
+
+// NewTranslators initializes all translators.
+func NewTranslators() map[Lang]Translator {
+	return map[Lang]Translator{
+		LangEn: newEn(),
+		LangPl: newPl(),
+	}
+}
+
+// BaseLang is the fallback language when none is set.
+const BaseLang = LangEn
+
+var (
+	defaultTranslators = sync.OnceValue(NewTranslators)
+	// installedTranslators holds the translators set with SetTranslators, if any.
+	installedTranslators atomic.Pointer[map[Lang]Translator]
+)
+
+// SetTranslators installs the translators used by T when the context carries none, by Render
+// and by the Error method of message errors, e.g. memoized, development or test translators.
+// They default to NewTranslators, which a nil map restores.
+func SetTranslators(tt map[Lang]Translator) {
+	if tt == nil {
+		installedTranslators.Store(nil)
+		return
+	}
+	installedTranslators.Store(&tt)
+}
+
+// translators returns the translators set with SetTranslators, or NewTranslators.
+func translators() map[Lang]Translator {
+	if tt := installedTranslators.Load(); tt != nil {
+		return *tt
+	}
+	return defaultTranslators()
+}
+
+type (
+	langContextKey        struct{}
+	translatorsContextKey struct{}
+)
+
+// WithLang returns a copy of ctx carrying lang.
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, langContextKey{}, lang)
+}
+
+// LangFromContext returns the language carried by ctx, or BaseLang if none is set.
+func LangFromContext(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(langContextKey{}).(Lang); ok {
+		return lang
+	}
+	return BaseLang
+}
+
+// WithTranslators returns a copy of ctx carrying the translators T uses instead of those set with SetTranslators.
+func WithTranslators(ctx context.Context, tt map[Lang]Translator) context.Context {
+	return context.WithValue(ctx, translatorsContextKey{}, tt)
+}
+
+// T returns the translator for the language carried by ctx, from the translators carried by ctx
+// or else those set with SetTranslators.
+// It falls back to BaseLang if no language is set or the language is not available.
+func T(ctx context.Context) Translator {
+	tt, ok := ctx.Value(translatorsContextKey{}).(map[Lang]Translator)
+	if !ok {
+		tt = translators()
+	}
+	if t, ok := tt[LangFromContext(ctx)]; ok {
+		return t
+	}
+	return tt[BaseLang]
+}
+
+var htmlReplacer = strings.NewReplacer(
+	"\x00", "\uFFFD",
+	`"`, "&#34;",
+	"&", "&amp;",
+	"'", "&#39;",
+	"+", "&#43;",
+	"<", "&lt;",
+	">", "&gt;",
+)
+
+// escapeHTML escapes s the same way html/template escapes values in text.
+func escapeHTML(s string) string {
+	return htmlReplacer.Replace(s)
+}
+
+// pluralForm returns the CLDR plural form of the integer n in tag by rules.
+func pluralForm[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr](rules *plural.Rules, tag language.Tag, n T) plural.Form {
+	abs := uint64(n)
+	if n < 0 {
+		abs = -abs
+	}
+	// Rules only depend on the last digits of integers beyond the int range of 32-bit platforms,
+	// kept above the values rules match exactly.
+	if abs > 1<<31-1 {
+		abs = abs%10_000_000 + 10_000_000
+	}
+	return rules.MatchPlural(tag, int(abs), 0, 0, 0, 0)
+}
+
+// enTag selects plural forms by en rules.
+var enTag = language.MustParse("en")
+
+type en struct{}
+
+func newEn() *en {
+	return &en{}
+}
+
+// FilesDeleted renders a properly translated message.
+func (t *en) FilesDeleted(files uint, folder string) (string, error) {
+	var b strings.Builder
+	form := pluralForm(plural.Cardinal, enTag, files)
+	switch {
+	case form == plural.One:
+		b.WriteString(strconv.FormatUint(uint64(files), 10))
+		b.WriteString(" file was deleted from ")
+		b.WriteString(escapeHTML(folder))
+		b.WriteString(".")
+	default:
+		b.WriteString(strconv.FormatUint(uint64(files), 10))
+		b.WriteString(" files were deleted from ")
+		b.WriteString(escapeHTML(folder))
+		b.WriteString(".")
+	}
+	return b.String(), nil
+}
+
+// LeaderboardRank renders a properly translated message.
+func (t *en) LeaderboardRank(name string, rank int) (string, error) {
+	var b strings.Builder
+	form := pluralForm(plural.Ordinal, enTag, rank)
+	switch {
+	case form == plural.One:
+		b.WriteString(escapeHTML(name))
+		b.WriteString(" finished ")
+		b.WriteString(strconv.Itoa(rank))
+		b.WriteString("st.")
+	case form == plural.Two:
+		b.WriteString(escapeHTML(name))
+		b.WriteString(" finished ")
+		b.WriteString(strconv.Itoa(rank))
+		b.WriteString("nd.")
+	case form == plural.Few:
+		b.WriteString(escapeHTML(name))
+		b.WriteString(" finished ")
+		b.WriteString(strconv.Itoa(rank))
+		b.WriteString("rd.")
+	default:
+		b.WriteString(escapeHTML(name))
+		b.WriteString(" finished ")
+		b.WriteString(strconv.Itoa(rank))
+		b.WriteString("th.")
+	}
+	return b.String(), nil
+}
+
+// UnreadMessages renders a properly translated message.
+func (t *en) UnreadMessages(count int) (string, error) {
+	var b strings.Builder
+	form := pluralForm(plural.Cardinal, enTag, count)
+	switch {
+	case count == 0:
+		b.WriteString("You have no unread messages.")
+	case form == plural.One:
+		b.WriteString("You have ")
+		b.WriteString(strconv.Itoa(count))
+		b.WriteString(" unread message.")
+	default:
+		b.WriteString("You have ")
+		b.WriteString(strconv.Itoa(count))
+		b.WriteString(" unread messages.")
+	}
+	return b.String(), nil
+}
+
+// plTag selects plural forms by pl rules.
+var plTag = language.MustParse("pl")
+
+type pl struct{}
+
+func newPl() *pl {
+	return &pl{}
+}
+
+// FilesDeleted renders a properly translated message.
+func (t *pl) FilesDeleted(files uint, folder string) (string, error) {
+	var b strings.Builder
+	form := pluralForm(plural.Cardinal, plTag, files)
+	switch {
+	case form == plural.One:
+		b.WriteString(strconv.FormatUint(uint64(files), 10))
+		b.WriteString(" plik został usunięty z ")
+		b.WriteString(escapeHTML(folder))
+		b.WriteString(".")
+	case form == plural.Few:
+		b.WriteString(strconv.FormatUint(uint64(files), 10))
+		b.WriteString(" pliki zostały usunięte z ")
+		b.WriteString(escapeHTML(folder))
+		b.WriteString(".")
+	case form == plural.Many:
+		b.WriteString(strconv.FormatUint(uint64(files), 10))
+		b.WriteString(" plików zostało usuniętych z ")
+		b.WriteString(escapeHTML(folder))
+		b.WriteString(".")
+	default:
+		b.WriteString(strconv.FormatUint(uint64(files), 10))
+		b.WriteString(" pliku zostało usunięte z ")
+		b.WriteString(escapeHTML(folder))
+		b.WriteString(".")
+	}
+	return b.String(), nil
+}
+
+// LeaderboardRank renders a properly translated message.
+func (t *pl) LeaderboardRank(name string, rank int) (string, error) {
+	var b strings.Builder
+	b.WriteString(escapeHTML(name))
+	b.WriteString(" zajmuje ")
+	b.WriteString(strconv.Itoa(rank))
+	b.WriteString(". miejsce.")
+	return b.String(), nil
+}
+
+// UnreadMessages renders a properly translated message.
+func (t *pl) UnreadMessages(count int) (string, error) {
+	var b strings.Builder
+	form := pluralForm(plural.Cardinal, plTag, count)
+	switch {
+	case count == 0:
+		b.WriteString("Nie masz nieprzeczytanych wiadomości.")
+	case form == plural.One:
+		b.WriteString("Masz ")
+		b.WriteString(strconv.Itoa(count))
+		b.WriteString(" nieprzeczytaną wiadomość.")
+	case form == plural.Few:
+		b.WriteString("Masz ")
+		b.WriteString(strconv.Itoa(count))
+		b.WriteString(" nieprzeczytane wiadomości.")
+	case form == plural.Many:
+		b.WriteString("Masz ")
+		b.WriteString(strconv.Itoa(count))
+		b.WriteString(" nieprzeczytanych wiadomości.")
+	default:
+		b.WriteString("Masz ")
+		b.WriteString(strconv.Itoa(count))
+		b.WriteString(" nieprzeczytanej wiadomości.")
+	}
+	return b.String(), nil
+}
+
+// NewDevTranslators initializes translators reading translation files in fsys at runtime,
+// parsing templates again whenever the files change, for local development.
+// Only message text is live: regenerate after changing variables or custom template expressions.
+func NewDevTranslators(fsys fs.FS, opts ...i18ndev.Option) map[Lang]Translator {
+	source := i18ndev.NewSource(fsys, ".", opts...)
+	return map[Lang]Translator{
+		LangEn: &devEn{source: source},
+		LangPl: &devPl{source: source},
+	}
+}
+
+type devEn struct {
+	source *i18ndev.Source
+}
+
+// FilesDeleted renders a translated message from the current translation files.
+func (t *devEn) FilesDeleted(files uint, folder string) (string, error) {
+	data := struct {
+		Files  uint
+		Folder string
+	}{
+		Files:  files,
+		Folder: folder,
+	}
+	condition := ""
+	form := pluralForm(plural.Cardinal, enTag, files)
+	switch {
+	case form == plural.One:
+		condition = "Files is plural one"
+	}
+	tmpl, err := t.source.Template("en", "files_deleted", condition, "Files is plural one")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// LeaderboardRank renders a translated message from the current translation files.
+func (t *devEn) LeaderboardRank(name string, rank int) (string, error) {
+	data := struct {
+		Name string
+		Rank int
+	}{
+		Name: name,
+		Rank: rank,
+	}
+	condition := ""
+	form := pluralForm(plural.Ordinal, enTag, rank)
+	switch {
+	case form == plural.One:
+		condition = "Rank is ordinal one"
+	case form == plural.Two:
+		condition = "Rank is ordinal two"
+	case form == plural.Few:
+		condition = "Rank is ordinal few"
+	}
+	tmpl, err := t.source.Template("en", "leaderboard_rank", condition, "Rank is ordinal one", "Rank is ordinal two", "Rank is ordinal few")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// UnreadMessages renders a translated message from the current translation files.
+func (t *devEn) UnreadMessages(count int) (string, error) {
+	data := struct {
+		Count int
+	}{
+		Count: count,
+	}
+	condition := ""
+	form := pluralForm(plural.Cardinal, enTag, count)
+	switch {
+	case count == 0:
+		condition = "count == 0"
+	case form == plural.One:
+		condition = "Count is plural one"
+	}
+	tmpl, err := t.source.Template("en", "unread_messages", condition, "count == 0", "Count is plural one")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+type devPl struct {
+	source *i18ndev.Source
+}
+
+// FilesDeleted renders a translated message from the current translation files.
+func (t *devPl) FilesDeleted(files uint, folder string) (string, error) {
+	data := struct {
+		Files  uint
+		Folder string
+	}{
+		Files:  files,
+		Folder: folder,
+	}
+	condition := ""
+	form := pluralForm(plural.Cardinal, plTag, files)
+	switch {
+	case form == plural.One:
+		condition = "Files is plural one"
+	case form == plural.Few:
+		condition = "Files is plural few"
+	case form == plural.Many:
+		condition = "Files is plural many"
+	}
+	tmpl, err := t.source.Template("pl", "files_deleted", condition, "Files is plural one", "Files is plural few", "Files is plural many")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// LeaderboardRank renders a translated message from the current translation files.
+func (t *devPl) LeaderboardRank(name string, rank int) (string, error) {
+	data := struct {
+		Name string
+		Rank int
+	}{
+		Name: name,
+		Rank: rank,
+	}
+	tmpl, err := t.source.Template("pl", "leaderboard_rank", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// UnreadMessages renders a translated message from the current translation files.
+func (t *devPl) UnreadMessages(count int) (string, error) {
+	data := struct {
+		Count int
+	}{
+		Count: count,
+	}
+	condition := ""
+	form := pluralForm(plural.Cardinal, plTag, count)
+	switch {
+	case count == 0:
+		condition = "count == 0"
+	case form == plural.One:
+		condition = "Count is plural one"
+	case form == plural.Few:
+		condition = "Count is plural few"
+	case form == plural.Many:
+		condition = "Count is plural many"
+	}
+	tmpl, err := t.source.Template("pl", "unread_messages", condition, "count == 0", "Count is plural one", "Count is plural few", "Count is plural many")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,218 @@
+// Code generated by i18ngo. DO NOT EDIT.
+
+/** Lang represents available translated languages. */
+export type Lang = "en" | "pl";
+
+/** langs holds all available languages. */
+export const langs: readonly Lang[] = ["en", "pl"];
+
+/** baseLang is the fallback language when none is set. */
+export const baseLang: Lang = "en";
+
+/** Translator is implemented by all language translators. */
+export interface Translator {
+  filesDeleted(files: number, folder: string): string;
+  leaderboardRank(name: string, rank: number): string;
+  unreadMessages(count: number): string;
+}
+
+const htmlEscapes: Record<string, string> = {
+  "\u0000": "�",
+  '"': "&#34;",
+  "&": "&amp;",
+  "'": "&#39;",
+  "+": "&#43;",
+  "<": "&lt;",
+  ">": "&gt;",
+};
+
+/** escapeHTML escapes s the same way Go's html/template escapes values in text. */
+function escapeHTML(s: string): string {
+  return s.replace(/[\u0000"&'+<>]/g, (c) => htmlEscapes[c]);
+}
+
+/** formatFloat formats x the same way Go formats float64 values. */
+function formatFloat(x: number): string {
+  if (Number.isNaN(x)) return "NaN";
+  if (!Number.isFinite(x)) return x > 0 ? "+Inf" : "-Inf";
+  if (x === 0) return Object.is(x, -0) ? "-0" : "0";
+  const [mantissa, exponent] = x.toExponential().split("e");
+  const exp = Number(exponent);
+  if (exp < -4 || exp >= 6) {
+    return mantissa + "e" + (exp < 0 ? "-" : "+") + String(Math.abs(exp)).padStart(2, "0");
+  }
+  return String(x);
+}
+
+/** formatValue formats values of unknown type the same way Go does. */
+function formatValue(x: unknown): string {
+  if (typeof x === "number" && !Number.isInteger(x)) return formatFloat(x);
+  return String(x);
+}
+
+/** truthy matches Go template truthiness for values of unknown type. */
+function truthy(x: unknown): boolean {
+  return Array.isArray(x) ? x.length > 0 : Boolean(x);
+}
+
+/** byteLength returns the length of s in UTF-8 bytes, like Go's len. */
+function byteLength(s: string): number {
+  return new TextEncoder().encode(s).length;
+}
+
+const translatorEn: Translator = {
+  filesDeleted(files: number, folder: string): string {
+    if (new Intl.PluralRules("en").select(files) === "one") {
+      let s = "";
+      s += escapeHTML(String(files));
+      s += " file was deleted from ";
+      s += escapeHTML(folder);
+      s += ".";
+      return s;
+    }
+    let s = "";
+    s += escapeHTML(String(files));
+    s += " files were deleted from ";
+    s += escapeHTML(folder);
+    s += ".";
+    return s;
+  },
+  leaderboardRank(name: string, rank: number): string {
+    if (new Intl.PluralRules("en", { type: "ordinal" }).select(rank) === "one") {
+      let s = "";
+      s += escapeHTML(name);
+      s += " finished ";
+      s += escapeHTML(String(rank));
+      s += "st.";
+      return s;
+    }
+    if (new Intl.PluralRules("en", { type: "ordinal" }).select(rank) === "two") {
+      let s = "";
+      s += escapeHTML(name);
+      s += " finished ";
+      s += escapeHTML(String(rank));
+      s += "nd.";
+      return s;
+    }
+    if (new Intl.PluralRules("en", { type: "ordinal" }).select(rank) === "few") {
+      let s = "";
+      s += escapeHTML(name);
+      s += " finished ";
+      s += escapeHTML(String(rank));
+      s += "rd.";
+      return s;
+    }
+    let s = "";
+    s += escapeHTML(name);
+    s += " finished ";
+    s += escapeHTML(String(rank));
+    s += "th.";
+    return s;
+  },
+  unreadMessages(count: number): string {
+    if (count === 0) {
+      let s = "";
+      s += "You have no unread messages.";
+      return s;
+    }
+    if (new Intl.PluralRules("en").select(count) === "one") {
+      let s = "";
+      s += "You have ";
+      s += escapeHTML(String(count));
+      s += " unread message.";
+      return s;
+    }
+    let s = "";
+    s += "You have ";
+    s += escapeHTML(String(count));
+    s += " unread messages.";
+    return s;
+  },
+};
+
+const translatorPl: Translator = {
+  filesDeleted(files: number, folder: string): string {
+    if (new Intl.PluralRules("pl").select(files) === "one") {
+      let s = "";
+      s += escapeHTML(String(files));
+      s += " plik został usunięty z ";
+      s += escapeHTML(folder);
+      s += ".";
+      return s;
+    }
+    if (new Intl.PluralRules("pl").select(files) === "few") {
+      let s = "";
+      s += escapeHTML(String(files));
+      s += " pliki zostały usunięte z ";
+      s += escapeHTML(folder);
+      s += ".";
+      return s;
+    }
+    if (new Intl.PluralRules("pl").select(files) === "many") {
+      let s = "";
+      s += escapeHTML(String(files));
+      s += " plików zostało usuniętych z ";
+      s += escapeHTML(folder);
+      s += ".";
+      return s;
+    }
+    let s = "";
+    s += escapeHTML(String(files));
+    s += " pliku zostało usunięte z ";
+    s += escapeHTML(folder);
+    s += ".";
+    return s;
+  },
+  leaderboardRank(name: string, rank: number): string {
+    let s = "";
+    s += escapeHTML(name);
+    s += " zajmuje ";
+    s += escapeHTML(String(rank));
+    s += ". miejsce.";
+    return s;
+  },
+  unreadMessages(count: number): string {
+    if (count === 0) {
+      let s = "";
+      s += "Nie masz nieprzeczytanych wiadomości.";
+      return s;
+    }
+    if (new Intl.PluralRules("pl").select(count) === "one") {
+      let s = "";
+      s += "Masz ";
+      s += escapeHTML(String(count));
+      s += " nieprzeczytaną wiadomość.";
+      return s;
+    }
+    if (new Intl.PluralRules("pl").select(count) === "few") {
+      let s = "";
+      s += "Masz ";
+      s += escapeHTML(String(count));
+      s += " nieprzeczytane wiadomości.";
+      return s;
+    }
+    if (new Intl.PluralRules("pl").select(count) === "many") {
+      let s = "";
+      s += "Masz ";
+      s += escapeHTML(String(count));
+      s += " nieprzeczytanych wiadomości.";
+      return s;
+    }
+    let s = "";
+    s += "Masz ";
+    s += escapeHTML(String(count));
+    s += " nieprzeczytanej wiadomości.";
+    return s;
+  },
+};
+
+/** translators holds a translator per language. */
+export const translators: Record<Lang, Translator> = {
+  "en": translatorEn,
+  "pl": translatorPl,
+};
+
+/** t returns the translator for lang, falling back to baseLang if lang is not available. */
+export function t(lang: string): Translator {
+  return translators[lang as Lang] ?? translators[baseLang];
+}
@@ -0,0 +1,114 @@
+// Code generated by i18ngo. DO NOT EDIT.
+package translations
+
+import (
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	t.Parallel()
+
+	tt := NewTranslators()
+	tests := []struct {
+		name   string
+		lang   Lang
+		render func(Translator) (string, error)
+		want   string
+	}{
+		{
+			name:   "en/leaderboard_rank/0",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.LeaderboardRank("Ana", 1) },
+			want:   "Ana finished 1st.",
+		},
+		{
+			name:   "en/leaderboard_rank/1",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.LeaderboardRank("Ana", 22) },
+			want:   "Ana finished 22nd.",
+		},
+		{
+			name:   "en/leaderboard_rank/2",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.LeaderboardRank("Ana", 103) },
+			want:   "Ana finished 103rd.",
+		},
+		{
+			name:   "en/leaderboard_rank/3",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.LeaderboardRank("Ana", 11) },
+			want:   "Ana finished 11th.",
+		},
+		{
+			name:   "en/unread_messages/0",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.UnreadMessages(0) },
+			want:   "You have no unread messages.",
+		},
+		{
+			name:   "en/unread_messages/1",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.UnreadMessages(1) },
+			want:   "You have 1 unread message.",
+		},
+		{
+			name:   "en/unread_messages/2",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.UnreadMessages(22) },
+			want:   "You have 22 unread messages.",
+		},
+		{
+			name:   "pl/leaderboard_rank/0",
+			lang:   LangPl,
+			render: func(tr Translator) (string, error) { return tr.LeaderboardRank("Ana", 2) },
+			want:   "Ana zajmuje 2. miejsce.",
+		},
+		{
+			name:   "pl/unread_messages/0",
+			lang:   LangPl,
+			render: func(tr Translator) (string, error) { return tr.UnreadMessages(0) },
+			want:   "Nie masz nieprzeczytanych wiadomości.",
+		},
+		{
+			name:   "pl/unread_messages/1",
+			lang:   LangPl,
+			render: func(tr Translator) (string, error) { return tr.UnreadMessages(1) },
+			want:   "Masz 1 nieprzeczytaną wiadomość.",
+		},
+		{
+			name:   "pl/unread_messages/2",
+			lang:   LangPl,
+			render: func(tr Translator) (string, error) { return tr.UnreadMessages(22) },
+			want:   "Masz 22 nieprzeczytane wiadomości.",
+		},
+		{
+			name:   "pl/unread_messages/3",
+			lang:   LangPl,
+			render: func(tr Translator) (string, error) { return tr.UnreadMessages(25) },
+			want:   "Masz 25 nieprzeczytanych wiadomości.",
+		},
+		{
+			name:   "pl/unread_messages/4",
+			lang:   LangPl,
+			render: func(tr Translator) (string, error) { return tr.UnreadMessages(112) },
+			want:   "Masz 112 nieprzeczytanych wiadomości.",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tr, ok := tt[tc.lang]
+			if !ok {
+				t.Skipf("%s is not compiled in", tc.lang)
+			}
+			got, err := tc.render(tr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -3,12 +3,12 @@ package translations
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"html/template"
-	"time"
-
-	"github.com/kofalt/go-memoize"
-	"github.com/patrickmn/go-cache"
+	"reflect"
+	"sync"
+	"sync/atomic"
 )
 
 // Translator is implemented by all language translators.
@@ -16,6 +16,13 @@ type Translator interface {
 	MyGreeting(age interface{}, name string) (string, error)
 }
 
+// MessageID identifies a message.
+type MessageID string
+
+const (
+	MessageIDMyGreeting MessageID = "my_greeting"
+)
+
 // Lang represents available translated languages.
 type Lang string
 
@@ -24,41 +31,148 @@ const (
 	LangEs Lang = "es"
 )
 
-// MemoizedTranslator wraps a Translator with a cache.
-type MemoizedTranslator struct {
-	translator Translator
-	cache      *memoize.Memoizer
+// NewTranslators initializes all translators.
+func NewTranslators() map[Lang]Translator {
+	return map[Lang]Translator{
+		LangEn: newEn(),
+		LangEs: newEs(),
+	}
 }
 
-// NewMemoizedTranslator initializes a memoized Translator.
-func NewMemoizedTranslator(translator Translator) *MemoizedTranslator {
-	cache := memoize.NewMemoizer(cache.NoExpiration, 1*time.Hour)
-	return &MemoizedTranslator{
-		translator: translator,
-		cache:      cache,
+// BaseLang is the fallback language when none is set.
+const BaseLang = LangEn
+
+var (
+	defaultTranslators = sync.OnceValue(NewTranslators)
+	// installedTranslators holds the translators set with SetTranslators, if any.
+	installedTranslators atomic.Pointer[map[Lang]Translator]
+)
+
+// SetTranslators installs the translators used by T when the context carries none, by Render
+// and by the Error method of message errors, e.g. memoized, development or test translators.
+// They default to NewTranslators, which a nil map restores.
+func SetTranslators(tt map[Lang]Translator) {
+	if tt == nil {
+		installedTranslators.Store(nil)
+		return
 	}
+	installedTranslators.Store(&tt)
 }
 
-// MyGreeting checks the cache or computes the message if not already cached.
-func (m *MemoizedTranslator) MyGreeting(age interface{}, name string) (string, error) {
-	cacheKey := fmt.Sprintf("En:MyGreeting:%v:%v:", age, name)
+// translators returns the translators set with SetTranslators, or NewTranslators.
+func translators() map[Lang]Translator {
+	if tt := installedTranslators.Load(); tt != nil {
+		return *tt
+	}
+	return defaultTranslators()
+}
 
-	result, _, _ := m.cache.Memoize(cacheKey, func() (interface{}, error) {
-		return m.translator.MyGreeting(age, name)
-	})
+type (
+	langContextKey        struct{}
+	translatorsContextKey struct{}
+)
 
-	if err, ok := result.(error); ok {
-		return "", err
+// WithLang returns a copy of ctx carrying lang.
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, langContextKey{}, lang)
+}
+
+// LangFromContext returns the language carried by ctx, or BaseLang if none is set.
+func LangFromContext(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(langContextKey{}).(Lang); ok {
+		return lang
 	}
-	return result.(string), nil
+	return BaseLang
 }
 
-// NewTranslators initializes all translators.
-func NewTranslators() map[Lang]Translator {
-	return map[Lang]Translator{
-		LangEn: newEn(),
-		LangEs: newEs(),
+// WithTranslators returns a copy of ctx carrying the translators T uses instead of those set with SetTranslators.
+func WithTranslators(ctx context.Context, tt map[Lang]Translator) context.Context {
+	return context.WithValue(ctx, translatorsContextKey{}, tt)
+}
+
+// T returns the translator for the language carried by ctx, from the translators carried by ctx
+// or else those set with SetTranslators.
+// It falls back to BaseLang if no language is set or the language is not available.
+func T(ctx context.Context) Translator {
+	tt, ok := ctx.Value(translatorsContextKey{}).(map[Lang]Translator)
+	if !ok {
+		tt = translators()
+	}
+	if t, ok := tt[LangFromContext(ctx)]; ok {
+		return t
+	}
+	return tt[BaseLang]
+}
+
+// UnknownMessageError is returned by Render for an unknown message id.
+type UnknownMessageError struct {
+	ID MessageID
+}
+
+func (e *UnknownMessageError) Error() string {
+	return fmt.Sprintf("unknown message %q", e.ID)
+}
+
+// MissingArgumentError is returned by Render when a message argument is missing.
+type MissingArgumentError struct {
+	ID  MessageID
+	Arg string
+}
+
+func (e *MissingArgumentError) Error() string {
+	return fmt.Sprintf("message %q: missing argument %s", e.ID, e.Arg)
+}
+
+// InvalidArgumentError is returned by Render when a message argument has the wrong type.
+type InvalidArgumentError struct {
+	ID    MessageID
+	Arg   string
+	Type  string
+	Value any
+}
+
+func (e *InvalidArgumentError) Error() string {
+	return fmt.Sprintf("message %q: argument %s must be %s, got %T", e.ID, e.Arg, e.Type, e.Value)
+}
+
+// Render renders a message by id with arguments keyed by variable name.
+// It uses the translator for lang set with SetTranslators, falling back to BaseLang if lang is not available.
+func Render(lang Lang, id MessageID, args map[string]any) (string, error) {
+	tt := translators()
+	t, ok := tt[lang]
+	if !ok {
+		t = tt[BaseLang]
+	}
+	switch id {
+	case MessageIDMyGreeting:
+		argAge, err := renderArg[interface{}](id, args, "Age")
+		if err != nil {
+			return "", err
+		}
+		argName, err := renderArg[string](id, args, "Name")
+		if err != nil {
+			return "", err
+		}
+		return t.MyGreeting(argAge, argName)
+	}
+	return "", &UnknownMessageError{ID: id}
+}
+
+func renderArg[T any](id MessageID, args map[string]any, name string) (T, error) {
+	var zero T
+	v, ok := args[name]
+	if !ok {
+		return zero, &MissingArgumentError{ID: id, Arg: name}
+	}
+	typ := reflect.TypeFor[T]()
+	if v == nil && typ.Kind() == reflect.Interface {
+		return zero, nil
+	}
+	arg, ok := v.(T)
+	if !ok {
+		return zero, &InvalidArgumentError{ID: id, Arg: name, Type: typ.String(), Value: v}
 	}
+	return arg, nil
 }
 
 type en struct {
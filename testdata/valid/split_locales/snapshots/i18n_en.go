@@ -0,0 +1,81 @@
+// Code generated by i18ngo. DO NOT EDIT.
+package translations
+
+import (
+	"bytes"
+	"html/template"
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+var _ = registerLocale(LangEn, locale{
+	tag:     language.MustParse("en"),
+	new:     func() Translator { return newEn() },
+	preload: func() error { return loadEnTemplates().preload() },
+})
+
+type en struct{}
+
+func newEn() *en {
+	return &en{}
+}
+
+// enTemplates holds en templates, each parsed on first use.
+type enTemplates struct {
+	MyGreetingDft     func() (*template.Template, error)
+	MyGreetingCustom0 func() (*template.Template, error)
+	MyGreetingCustom1 func() (*template.Template, error)
+}
+
+// loadEnTemplates initializes en templates on first use of the language.
+var loadEnTemplates = sync.OnceValue(func() *enTemplates {
+	return &enTemplates{
+		MyGreetingDft:     lazyTemplate("MyGreeting", "Hello {{ .Name }}! You have {{ .Count }} messages."),
+		MyGreetingCustom0: lazyTemplate("MyGreetingCustom0", "Hello {{ .Name }}! You have {{ .Count }} message."),
+		MyGreetingCustom1: lazyTemplate("MyGreetingCustom1", "Hello {{ .Name }}! You have no messages."),
+	}
+})
+
+func (t *enTemplates) preload() error {
+	for _, load := range []func() (*template.Template, error){
+		t.MyGreetingDft,
+		t.MyGreetingCustom0,
+		t.MyGreetingCustom1,
+	} {
+		if _, err := load(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MyGreeting renders a properly translated message.
+func (t *en) MyGreeting(count int, name string) (string, error) {
+	data := struct {
+		Count int
+		Name  string
+	}{
+		Count: count,
+		Name:  name,
+	}
+	tmpls := loadEnTemplates()
+	var load func() (*template.Template, error)
+	switch {
+	case count == 1:
+		load = tmpls.MyGreetingCustom0
+	case count == 0:
+		load = tmpls.MyGreetingCustom1
+	default:
+		load = tmpls.MyGreetingDft
+	}
+	tmpl, err := load()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,83 @@
+//go:build i18n_es
+
+// Code generated by i18ngo. DO NOT EDIT.
+package translations
+
+import (
+	"bytes"
+	"html/template"
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+var _ = registerLocale(LangEs, locale{
+	tag:     language.MustParse("es"),
+	new:     func() Translator { return newEs() },
+	preload: func() error { return loadEsTemplates().preload() },
+})
+
+type es struct{}
+
+func newEs() *es {
+	return &es{}
+}
+
+// esTemplates holds es templates, each parsed on first use.
+type esTemplates struct {
+	MyGreetingDft     func() (*template.Template, error)
+	MyGreetingCustom0 func() (*template.Template, error)
+	MyGreetingCustom1 func() (*template.Template, error)
+}
+
+// loadEsTemplates initializes es templates on first use of the language.
+var loadEsTemplates = sync.OnceValue(func() *esTemplates {
+	return &esTemplates{
+		MyGreetingDft:     lazyTemplate("MyGreeting", "Hola {{ .Name }}! Tienes {{ .Count }} mensajes."),
+		MyGreetingCustom0: lazyTemplate("MyGreetingCustom0", "Hola {{ .Name }}! Tienes {{ .Count }} mensaje."),
+		MyGreetingCustom1: lazyTemplate("MyGreetingCustom1", "Hola {{ .Name }}! No tienes ningún mensaje."),
+	}
+})
+
+func (t *esTemplates) preload() error {
+	for _, load := range []func() (*template.Template, error){
+		t.MyGreetingDft,
+		t.MyGreetingCustom0,
+		t.MyGreetingCustom1,
+	} {
+		if _, err := load(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MyGreeting renders a properly translated message.
+func (t *es) MyGreeting(count int, name string) (string, error) {
+	data := struct {
+		Count int
+		Name  string
+	}{
+		Count: count,
+		Name:  name,
+	}
+	tmpls := loadEsTemplates()
+	var load func() (*template.Template, error)
+	switch {
+	case count == 1:
+		load = tmpls.MyGreetingCustom0
+	case count == 0:
+		load = tmpls.MyGreetingCustom1
+	default:
+		load = tmpls.MyGreetingDft
+	}
+	tmpl, err := load()
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,252 @@
+// Code generated by i18ngo. DO NOT EDIT.
+package translations
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/a-h/templ"
+)
+
+// Translator is implemented by all language translators.
+type Translator interface {
+	MyGreeting(count int, name string) (string, error)
+	NthItem(index int, items []string) (string, error)
+}
+
+// MessageID identifies a message.
+type MessageID string
+
+const (
+	MessageIDMyGreeting MessageID = "my_greeting"
+	MessageIDNthItem    MessageID = "nth_item"
+)
+
+// Lang represents available translated languages.
+type Lang string
+
+const (
+	LangEn Lang = "en"
+	LangEs Lang = "es"
+)
+
+// NewTranslators initializes all translators.
+func NewTranslators() map[Lang]Translator {
+	return map[Lang]Translator{
+		LangEn: newEn(),
+		LangEs: newEs(),
+	}
+}
+
+// BaseLang is the fallback language when none is set.
+const BaseLang = LangEn
+
+var (
+	defaultTranslators = sync.OnceValue(NewTranslators)
+	// installedTranslators holds the translators set with SetTranslators, if any.
+	installedTranslators atomic.Pointer[map[Lang]Translator]
+)
+
+// SetTranslators installs the translators used by T when the context carries none, by Render
+// and by the Error method of message errors, e.g. memoized, development or test translators.
+// They default to NewTranslators, which a nil map restores.
+func SetTranslators(tt map[Lang]Translator) {
+	if tt == nil {
+		installedTranslators.Store(nil)
+		return
+	}
+	installedTranslators.Store(&tt)
+}
+
+// translators returns the translators set with SetTranslators, or NewTranslators.
+func translators() map[Lang]Translator {
+	if tt := installedTranslators.Load(); tt != nil {
+		return *tt
+	}
+	return defaultTranslators()
+}
+
+type (
+	langContextKey        struct{}
+	translatorsContextKey struct{}
+)
+
+// WithLang returns a copy of ctx carrying lang.
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, langContextKey{}, lang)
+}
+
+// LangFromContext returns the language carried by ctx, or BaseLang if none is set.
+func LangFromContext(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(langContextKey{}).(Lang); ok {
+		return lang
+	}
+	return BaseLang
+}
+
+// WithTranslators returns a copy of ctx carrying the translators T uses instead of those set with SetTranslators.
+func WithTranslators(ctx context.Context, tt map[Lang]Translator) context.Context {
+	return context.WithValue(ctx, translatorsContextKey{}, tt)
+}
+
+// T returns the translator for the language carried by ctx, from the translators carried by ctx
+// or else those set with SetTranslators.
+// It falls back to BaseLang if no language is set or the language is not available.
+func T(ctx context.Context) Translator {
+	tt, ok := ctx.Value(translatorsContextKey{}).(map[Lang]Translator)
+	if !ok {
+		tt = translators()
+	}
+	if t, ok := tt[LangFromContext(ctx)]; ok {
+		return t
+	}
+	return tt[BaseLang]
+}
+
+// MyGreetingC renders MyGreeting as HTML with the translator for the language carried by the context.
+// Rendering errors are returned by Render.
+func MyGreetingC(count int, name string) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		s, err := T(ctx).MyGreeting(count, name)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, s)
+		return err
+	})
+}
+
+// NthItemC renders NthItem as HTML with the translator for the language carried by the context.
+// Rendering errors are returned by Render.
+func NthItemC(index int, items []string) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		s, err := T(ctx).NthItem(index, items)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, s)
+		return err
+	})
+}
+
+type en struct {
+	MyGreetingDft     *template.Template
+	MyGreetingCustom0 *template.Template
+	MyGreetingCustom1 *template.Template
+	NthItemDft        *template.Template
+}
+
+func newEn() *en {
+	return &en{
+		MyGreetingDft:     template.Must(template.New("MyGreeting").Parse("Hello {{ .Name }}! You have {{ .Count }} messages.")),
+		MyGreetingCustom0: template.Must(template.New("MyGreetingCustom0").Parse("Hello {{ .Name }}! You have {{ .Count }} message.")),
+		MyGreetingCustom1: template.Must(template.New("MyGreetingCustom1").Parse("Hello {{ .Name }}! You have no messages.")),
+		NthItemDft:        template.Must(template.New("NthItem").Parse("Item: {{ index .Items .Index }}")),
+	}
+}
+
+// MyGreeting renders a properly translated message.
+func (t *en) MyGreeting(count int, name string) (string, error) {
+	data := struct {
+		Count int
+		Name  string
+	}{
+		Count: count,
+		Name:  name,
+	}
+	var tmpl *template.Template
+	switch {
+	case count == 1:
+		tmpl = t.MyGreetingCustom0
+	case count == 0:
+		tmpl = t.MyGreetingCustom1
+	default:
+		tmpl = t.MyGreetingDft
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// NthItem renders a properly translated message.
+func (t *en) NthItem(index int, items []string) (string, error) {
+	data := struct {
+		Index int
+		Items []string
+	}{
+		Index: index,
+		Items: items,
+	}
+	var tmpl *template.Template
+	tmpl = t.NthItemDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+type es struct {
+	MyGreetingDft     *template.Template
+	MyGreetingCustom0 *template.Template
+	MyGreetingCustom1 *template.Template
+	NthItemDft        *template.Template
+}
+
+func newEs() *es {
+	return &es{
+		MyGreetingDft:     template.Must(template.New("MyGreeting").Parse("Hola {{ .Name }}! Tienes {{ .Count }} mensajes.")),
+		MyGreetingCustom0: template.Must(template.New("MyGreetingCustom0").Parse("Hola {{ .Name }}! Tienes {{ .Count }} mensaje.")),
+		MyGreetingCustom1: template.Must(template.New("MyGreetingCustom1").Parse("Hola {{ .Name }}! No tienes ningún mensaje.")),
+		NthItemDft:        template.Must(template.New("NthItem").Parse("Elemento: {{ index .Items .Index }}")),
+	}
+}
+
+// MyGreeting renders a properly translated message.
+func (t *es) MyGreeting(count int, name string) (string, error) {
+	data := struct {
+		Count int
+		Name  string
+	}{
+		Count: count,
+		Name:  name,
+	}
+	var tmpl *template.Template
+	switch {
+	case count == 1:
+		tmpl = t.MyGreetingCustom0
+	case count == 0:
+		tmpl = t.MyGreetingCustom1
+	default:
+		tmpl = t.MyGreetingDft
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// NthItem renders a properly translated message.
+func (t *es) NthItem(index int, items []string) (string, error) {
+	data := struct {
+		Index int
+		Items []string
+	}{
+		Index: index,
+		Items: items,
+	}
+	var tmpl *template.Template
+	tmpl = t.NthItemDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,1622 @@
+// Code generated by i18ngo. DO NOT EDIT.
+package translations
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+
+	"github.com/danicc097/i18ngo/i18ndev"
+
+	"github.com/danicc097/i18ngo/i18nrt"
+)
+
+// Translator is implemented by all language translators.
+type Translator interface {
+	Appointment(args AppointmentArgs) (string, error)
+	CityBanner(args CityBannerArgs) (string, error)
+	DueDate(args DueDateArgs) (string, error)
+	Greeting(args GreetingArgs) (string, error)
+	InvoiceTotal(args InvoiceTotalArgs) (string, error)
+	LastLogin(args LastLoginArgs) (string, error)
+	SessionExpiry(args SessionExpiryArgs) (string, error)
+	Stats(args StatsArgs) (string, error)
+	Summary(args SummaryArgs) (string, error)
+	TagList(args TagListArgs) (string, error)
+	Total(args TotalArgs) (string, error)
+}
+
+// AppointmentArgs holds the arguments of Appointment.
+type AppointmentArgs struct {
+	At time.Time
+}
+
+// CityBannerArgs holds the arguments of CityBanner.
+type CityBannerArgs struct {
+	City string
+}
+
+// DueDateArgs holds the arguments of DueDate.
+type DueDateArgs struct {
+	Due time.Time
+}
+
+// GreetingArgs holds the arguments of Greeting.
+type GreetingArgs struct {
+	Count int
+	Name  string
+}
+
+// InvoiceTotalArgs holds the arguments of InvoiceTotal.
+type InvoiceTotalArgs struct {
+	Amount i18nrt.Money
+	Code   string
+	Tax    float64
+}
+
+// LastLoginArgs holds the arguments of LastLogin.
+type LastLoginArgs struct {
+	At time.Time
+}
+
+// SessionExpiryArgs holds the arguments of SessionExpiry.
+type SessionExpiryArgs struct {
+	Left time.Duration
+}
+
+// StatsArgs holds the arguments of Stats.
+type StatsArgs struct {
+	Active float64
+	Score  float64
+	Users  int
+}
+
+// SummaryArgs holds the arguments of Summary.
+type SummaryArgs struct {
+	Text string
+}
+
+// TagListArgs holds the arguments of TagList.
+type TagListArgs struct {
+	Tags []string
+}
+
+// TotalArgs holds the arguments of Total.
+type TotalArgs struct {
+	Count int
+}
+
+// MessageID identifies a message.
+type MessageID string
+
+const (
+	MessageIDAppointment   MessageID = "appointment"
+	MessageIDCityBanner    MessageID = "city_banner"
+	MessageIDDueDate       MessageID = "due_date"
+	MessageIDGreeting      MessageID = "greeting"
+	MessageIDInvoiceTotal  MessageID = "invoice_total"
+	MessageIDLastLogin     MessageID = "last_login"
+	MessageIDSessionExpiry MessageID = "session_expiry"
+	MessageIDStats         MessageID = "stats"
+	MessageIDSummary       MessageID = "summary"
+	MessageIDTagList       MessageID = "tag_list"
+	MessageIDTotal         MessageID = "total"
+)
+
+// Lang represents available translated languages.
+type Lang string
+
+const (
+	LangEn Lang = "en"
+	LangTr Lang = "tr"
+)
+
+// DefaultMemoCacheSize is the default maximum number of messages in a MemoCache.
+const DefaultMemoCacheSize = 1024
+
+// MemoCache is a concurrency-safe, size-bounded LRU cache of rendered messages.
+// It may be shared by memoized translators of different languages.
+type MemoCache struct {
+	mu        sync.Mutex
+	size      int
+	ttl       time.Duration
+	now       func() time.Time
+	entries   map[string]*list.Element
+	lru       *list.List
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+type memoEntry struct {
+	key     string
+	value   string
+	expires time.Time
+}
+
+// MemoStats holds MemoCache statistics.
+type MemoStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Len       int
+}
+
+// MemoCacheOption configures a MemoCache.
+type MemoCacheOption func(*MemoCache)
+
+// WithMemoSize sets the maximum number of cached messages.
+// It panics if size is not positive, since the cache is always bounded.
+func WithMemoSize(size int) MemoCacheOption {
+	if size <= 0 {
+		panic(fmt.Sprintf("memo cache size must be positive, got %d", size))
+	}
+	return func(c *MemoCache) {
+		c.size = size
+	}
+}
+
+// WithMemoTTL sets how long rendered messages are cached. Zero means no expiration.
+func WithMemoTTL(ttl time.Duration) MemoCacheOption {
+	return func(c *MemoCache) {
+		c.ttl = ttl
+	}
+}
+
+// WithMemoClock sets the clock entries expire by. It defaults to time.Now.
+func WithMemoClock(now func() time.Time) MemoCacheOption {
+	return func(c *MemoCache) {
+		c.now = now
+	}
+}
+
+// NewMemoCache initializes a MemoCache holding up to DefaultMemoCacheSize messages without expiration.
+func NewMemoCache(opts ...MemoCacheOption) *MemoCache {
+	c := &MemoCache{
+		size:    DefaultMemoCacheSize,
+		now:     time.Now,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// Stats returns cache statistics.
+func (c *MemoCache) Stats() MemoStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return MemoStats{Hits: c.hits, Misses: c.misses, Evictions: c.evictions, Len: c.lru.Len()}
+}
+
+func (c *MemoCache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return "", false
+	}
+	entry := el.Value.(*memoEntry)
+	if c.ttl > 0 && c.now().After(entry.expires) {
+		c.lru.Remove(el)
+		delete(c.entries, key)
+		c.misses++
+		return "", false
+	}
+	c.lru.MoveToFront(el)
+	c.hits++
+	return entry.value, true
+}
+
+func (c *MemoCache) add(key, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var expires time.Time
+	if c.ttl > 0 {
+		expires = c.now().Add(c.ttl)
+	}
+	if el, ok := c.entries[key]; ok {
+		el.Value = &memoEntry{key: key, value: value, expires: expires}
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&memoEntry{key: key, value: value, expires: expires})
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoEntry).key)
+		c.evictions++
+	}
+}
+
+// MemoizedTranslator wraps a Translator with a cache.
+type MemoizedTranslator struct {
+	lang       Lang
+	translator Translator
+	cache      *MemoCache
+}
+
+// NewMemoizedTranslator initializes a memoized Translator with its own MemoCache with default options.
+func NewMemoizedTranslator(translator Translator) *MemoizedTranslator {
+	return NewMemoizedTranslatorWithCache("", translator, nil)
+}
+
+// NewMemoizedTranslatorWithCache initializes a memoized Translator for lang,
+// whose cache may be shared with memoized translators of other languages.
+// A new MemoCache with default options is used if cache is nil.
+func NewMemoizedTranslatorWithCache(lang Lang, translator Translator, cache *MemoCache) *MemoizedTranslator {
+	if cache == nil {
+		cache = NewMemoCache()
+	}
+	return &MemoizedTranslator{
+		lang:       lang,
+		translator: translator,
+		cache:      cache,
+	}
+}
+
+// NewMemoizedTranslators wraps all translators with a shared cache.
+// A new MemoCache with default options is used if cache is nil.
+func NewMemoizedTranslators(tt map[Lang]Translator, cache *MemoCache) map[Lang]Translator {
+	if cache == nil {
+		cache = NewMemoCache()
+	}
+	memoized := make(map[Lang]Translator, len(tt))
+	for lang, t := range tt {
+		memoized[lang] = NewMemoizedTranslatorWithCache(lang, t, cache)
+	}
+	return memoized
+}
+
+// Appointment checks the cache or computes the message if not already cached.
+func (m *MemoizedTranslator) Appointment(args AppointmentArgs) (string, error) {
+	cacheKey := fmt.Sprintf("%s\x00Appointment\x00%#v", m.lang, args.At)
+	if rendered, ok := m.cache.get(cacheKey); ok {
+		return rendered, nil
+	}
+
+	rendered, err := m.translator.Appointment(args)
+	if err != nil {
+		return "", err
+	}
+	m.cache.add(cacheKey, rendered)
+	return rendered, nil
+}
+
+// CityBanner checks the cache or computes the message if not already cached.
+func (m *MemoizedTranslator) CityBanner(args CityBannerArgs) (string, error) {
+	cacheKey := fmt.Sprintf("%s\x00CityBanner\x00%#v", m.lang, args.City)
+	if rendered, ok := m.cache.get(cacheKey); ok {
+		return rendered, nil
+	}
+
+	rendered, err := m.translator.CityBanner(args)
+	if err != nil {
+		return "", err
+	}
+	m.cache.add(cacheKey, rendered)
+	return rendered, nil
+}
+
+// DueDate checks the cache or computes the message if not already cached.
+func (m *MemoizedTranslator) DueDate(args DueDateArgs) (string, error) {
+	cacheKey := fmt.Sprintf("%s\x00DueDate\x00%#v", m.lang, args.Due)
+	if rendered, ok := m.cache.get(cacheKey); ok {
+		return rendered, nil
+	}
+
+	rendered, err := m.translator.DueDate(args)
+	if err != nil {
+		return "", err
+	}
+	m.cache.add(cacheKey, rendered)
+	return rendered, nil
+}
+
+// Greeting checks the cache or computes the message if not already cached.
+func (m *MemoizedTranslator) Greeting(args GreetingArgs) (string, error) {
+	cacheKey := fmt.Sprintf("%s\x00Greeting\x00%#v\x00%#v", m.lang, args.Count, args.Name)
+	if rendered, ok := m.cache.get(cacheKey); ok {
+		return rendered, nil
+	}
+
+	rendered, err := m.translator.Greeting(args)
+	if err != nil {
+		return "", err
+	}
+	m.cache.add(cacheKey, rendered)
+	return rendered, nil
+}
+
+// InvoiceTotal checks the cache or computes the message if not already cached.
+func (m *MemoizedTranslator) InvoiceTotal(args InvoiceTotalArgs) (string, error) {
+	cacheKey := fmt.Sprintf("%s\x00InvoiceTotal\x00%#v\x00%#v\x00%#v", m.lang, args.Amount, args.Code, args.Tax)
+	if rendered, ok := m.cache.get(cacheKey); ok {
+		return rendered, nil
+	}
+
+	rendered, err := m.translator.InvoiceTotal(args)
+	if err != nil {
+		return "", err
+	}
+	m.cache.add(cacheKey, rendered)
+	return rendered, nil
+}
+
+// LastLogin computes the message without caching it, since its relative times change as time passes.
+func (m *MemoizedTranslator) LastLogin(args LastLoginArgs) (string, error) {
+	return m.translator.LastLogin(args)
+}
+
+// SessionExpiry computes the message without caching it, since its relative times change as time passes.
+func (m *MemoizedTranslator) SessionExpiry(args SessionExpiryArgs) (string, error) {
+	return m.translator.SessionExpiry(args)
+}
+
+// Stats checks the cache or computes the message if not already cached.
+func (m *MemoizedTranslator) Stats(args StatsArgs) (string, error) {
+	cacheKey := fmt.Sprintf("%s\x00Stats\x00%#v\x00%#v\x00%#v", m.lang, args.Active, args.Score, args.Users)
+	if rendered, ok := m.cache.get(cacheKey); ok {
+		return rendered, nil
+	}
+
+	rendered, err := m.translator.Stats(args)
+	if err != nil {
+		return "", err
+	}
+	m.cache.add(cacheKey, rendered)
+	return rendered, nil
+}
+
+// Summary checks the cache or computes the message if not already cached.
+func (m *MemoizedTranslator) Summary(args SummaryArgs) (string, error) {
+	cacheKey := fmt.Sprintf("%s\x00Summary\x00%#v", m.lang, args.Text)
+	if rendered, ok := m.cache.get(cacheKey); ok {
+		return rendered, nil
+	}
+
+	rendered, err := m.translator.Summary(args)
+	if err != nil {
+		return "", err
+	}
+	m.cache.add(cacheKey, rendered)
+	return rendered, nil
+}
+
+// TagList checks the cache or computes the message if not already cached.
+func (m *MemoizedTranslator) TagList(args TagListArgs) (string, error) {
+	cacheKey := fmt.Sprintf("%s\x00TagList\x00%#v", m.lang, args.Tags)
+	if rendered, ok := m.cache.get(cacheKey); ok {
+		return rendered, nil
+	}
+
+	rendered, err := m.translator.TagList(args)
+	if err != nil {
+		return "", err
+	}
+	m.cache.add(cacheKey, rendered)
+	return rendered, nil
+}
+
+// Total checks the cache or computes the message if not already cached.
+func (m *MemoizedTranslator) Total(args TotalArgs) (string, error) {
+	cacheKey := fmt.Sprintf("%s\x00Total\x00%#v", m.lang, args.Count)
+	if rendered, ok := m.cache.get(cacheKey); ok {
+		return rendered, nil
+	}
+
+	rendered, err := m.translator.Total(args)
+	if err != nil {
+		return "", err
+	}
+	m.cache.add(cacheKey, rendered)
+	return rendered, nil
+}
+
+// NewTranslators initializes all translators.
+func NewTranslators() map[Lang]Translator {
+	return map[Lang]Translator{
+		LangEn: newEn(),
+		LangTr: newTr(),
+	}
+}
+
+// BaseLang is the fallback language when none is set.
+const BaseLang = LangEn
+
+var (
+	defaultTranslators = sync.OnceValue(NewTranslators)
+	// installedTranslators holds the translators set with SetTranslators, if any.
+	installedTranslators atomic.Pointer[map[Lang]Translator]
+)
+
+// SetTranslators installs the translators used by T when the context carries none, by Render
+// and by the Error method of message errors, e.g. memoized, development or test translators.
+// They default to NewTranslators, which a nil map restores.
+func SetTranslators(tt map[Lang]Translator) {
+	if tt == nil {
+		installedTranslators.Store(nil)
+		return
+	}
+	installedTranslators.Store(&tt)
+}
+
+// translators returns the translators set with SetTranslators, or NewTranslators.
+func translators() map[Lang]Translator {
+	if tt := installedTranslators.Load(); tt != nil {
+		return *tt
+	}
+	return defaultTranslators()
+}
+
+type (
+	langContextKey        struct{}
+	translatorsContextKey struct{}
+)
+
+// WithLang returns a copy of ctx carrying lang.
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, langContextKey{}, lang)
+}
+
+// LangFromContext returns the language carried by ctx, or BaseLang if none is set.
+func LangFromContext(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(langContextKey{}).(Lang); ok {
+		return lang
+	}
+	return BaseLang
+}
+
+// WithTranslators returns a copy of ctx carrying the translators T uses instead of those set with SetTranslators.
+func WithTranslators(ctx context.Context, tt map[Lang]Translator) context.Context {
+	return context.WithValue(ctx, translatorsContextKey{}, tt)
+}
+
+// T returns the translator for the language carried by ctx, from the translators carried by ctx
+// or else those set with SetTranslators.
+// It falls back to BaseLang if no language is set or the language is not available.
+func T(ctx context.Context) Translator {
+	tt, ok := ctx.Value(translatorsContextKey{}).(map[Lang]Translator)
+	if !ok {
+		tt = translators()
+	}
+	if t, ok := tt[LangFromContext(ctx)]; ok {
+		return t
+	}
+	return tt[BaseLang]
+}
+
+var htmlReplacer = strings.NewReplacer(
+	"\x00", "\uFFFD",
+	`"`, "&#34;",
+	"&", "&amp;",
+	"'", "&#39;",
+	"+", "&#43;",
+	"<", "&lt;",
+	">", "&gt;",
+)
+
+// escapeHTML escapes s the same way html/template escapes values in text.
+func escapeHTML(s string) string {
+	return htmlReplacer.Replace(s)
+}
+
+// templateFuncs returns the functions available in message templates, formatting values by the rules of tag
+// and the patterns of lf.
+func templateFuncs(tag language.Tag, lf localeFormats) map[string]any {
+	return map[string]any{
+		// Casers and printers are stateful, so one is created per call.
+		"upper": func(v any) string {
+			return cases.Upper(tag).String(fmt.Sprint(v))
+		},
+		"lower": func(v any) string {
+			return cases.Lower(tag).String(fmt.Sprint(v))
+		},
+		"title": func(v any) string {
+			return cases.Title(tag).String(fmt.Sprint(v))
+		},
+		"number": func(v any) (string, error) {
+			return formatNumber(tag, "number", number.Decimal(v), v)
+		},
+		"percent": func(v any) (string, error) {
+			return formatNumber(tag, "percent", number.Percent(v), v)
+		},
+		"decimal": func(v any, scale int) (string, error) {
+			return formatNumber(tag, "decimal", number.Decimal(v, number.Scale(scale)), v)
+		},
+		"date": func(t time.Time, style ...string) (string, error) {
+			i, err := timeStyle("date", style)
+			if err != nil {
+				return "", err
+			}
+			if err := lf.check("date", tag); err != nil {
+				return "", err
+			}
+			return lf.format(t, lf.date[i]), nil
+		},
+		"time": func(t time.Time, style ...string) (string, error) {
+			i, err := timeStyle("time", style)
+			if err != nil {
+				return "", err
+			}
+			if err := lf.check("time", tag); err != nil {
+				return "", err
+			}
+			return lf.format(t, lf.time[i]), nil
+		},
+		"datetime": func(t time.Time, style ...string) (string, error) {
+			i, err := timeStyle("datetime", style)
+			if err != nil {
+				return "", err
+			}
+			if err := lf.check("datetime", tag); err != nil {
+				return "", err
+			}
+			return lf.format(t, strings.NewReplacer("{0}", lf.time[i], "{1}", lf.date[i]).Replace(lf.dateTime[i])), nil
+		},
+		"relativeTime": func(v any) (string, error) {
+			if err := lf.check("relativeTime", tag); err != nil {
+				return "", err
+			}
+			return lf.relativeTime(tag, v)
+		},
+		"currency": func(amount any, code ...any) (string, error) {
+			if err := lf.check("currency", tag); err != nil {
+				return "", err
+			}
+			return lf.formatCurrency(tag, amount, code...)
+		},
+		"join":     joinFunc,
+		"truncate": truncateFunc,
+		"default":  defaultFunc,
+	}
+}
+
+// formatNumber formats v with f by the rules of tag, if v is a number.
+func formatNumber(tag language.Tag, name string, f number.Formatter, v any) (string, error) {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return message.NewPrinter(tag).Sprint(f), nil
+	}
+	return "", fmt.Errorf("%s: %T is not a number", name, v)
+}
+
+// localeFormats holds the CLDR date, time and currency patterns and names of a locale.
+type localeFormats struct {
+	// date, time and dateTime hold short, medium and long patterns.
+	// dateTime patterns combine a time {0} and a date {1}.
+	date, time, dateTime [3]string
+	months, shortMonths  [12]string
+	dayPeriods           [2]string // AM and PM
+	now                  string
+	// relative holds past and future patterns of a count {0} of each unit, by plural form.
+	relative map[string][2]map[string]string
+	// currency places a currency symbol ¤ and an amount #.
+	currency string
+	// decimal separates the integer and fractional digits of numbers.
+	decimal string
+}
+
+// check returns an error if lf holds no patterns, as for languages without CLDR data in i18ngo.
+func (lf localeFormats) check(name string, tag language.Tag) error {
+	if lf.now == "" {
+		return fmt.Errorf("%s: no patterns for %s", name, tag)
+	}
+	return nil
+}
+
+// timeStyle returns the index of the pattern for a short, medium or long style, medium by default.
+func timeStyle(name string, style []string) (int, error) {
+	if len(style) == 0 {
+		return 1, nil
+	}
+	if i := slices.Index([]string{"short", "medium", "long"}, style[0]); i >= 0 && len(style) == 1 {
+		return i, nil
+	}
+	return 0, fmt.Errorf("%s: unknown style %q, want short, medium or long", name, strings.Join(style, " "))
+}
+
+// format formats t with a CLDR date and time pattern.
+func (lf localeFormats) format(t time.Time, pattern string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		if c == '\'' {
+			end := strings.IndexByte(pattern[i+1:], '\'')
+			if end < 0 {
+				end = len(pattern) - i - 1
+			}
+			if end == 0 {
+				b.WriteByte('\'')
+			}
+			b.WriteString(pattern[i+1 : i+1+end])
+			i += end + 2
+			continue
+		}
+		n := 1
+		for i+n < len(pattern) && pattern[i+n] == c {
+			n++
+		}
+		i += n
+		pad := func(v int) string {
+			if n == 2 {
+				return fmt.Sprintf("%02d", v)
+			}
+			return strconv.Itoa(v)
+		}
+		switch c {
+		case 'y':
+			if n == 2 {
+				b.WriteString(fmt.Sprintf("%02d", t.Year()%100))
+			} else {
+				b.WriteString(strconv.Itoa(t.Year()))
+			}
+		case 'M':
+			switch n {
+			case 1, 2:
+				b.WriteString(pad(int(t.Month())))
+			case 3:
+				b.WriteString(lf.shortMonths[t.Month()-1])
+			default:
+				b.WriteString(lf.months[t.Month()-1])
+			}
+		case 'd':
+			b.WriteString(pad(t.Day()))
+		case 'H':
+			b.WriteString(pad(t.Hour()))
+		case 'h':
+			b.WriteString(pad((t.Hour()+11)%12 + 1))
+		case 'm':
+			b.WriteString(pad(t.Minute()))
+		case 's':
+			b.WriteString(pad(t.Second()))
+		case 'a':
+			b.WriteString(lf.dayPeriods[t.Hour()/12])
+		case 'z':
+			b.WriteString(t.Format("MST"))
+		default:
+			b.WriteString(strings.Repeat(string(c), n))
+		}
+	}
+	return b.String()
+}
+
+// relativeUnits are the units relative times are expressed in, largest first.
+// Years and months are calendar units counted in months, the others fixed durations.
+var relativeUnits = []struct {
+	name   string
+	months int
+	d      time.Duration
+}{
+	{name: "year", months: 12},
+	{name: "month", months: 1},
+	{name: "week", d: 7 * 24 * time.Hour},
+	{name: "day", d: 24 * time.Hour},
+	{name: "hour", d: time.Hour},
+	{name: "minute", d: time.Minute},
+	{name: "second", d: time.Second},
+}
+
+// pluralForms are the CLDR names of plural forms.
+var pluralForms = [...]string{
+	plural.Other: "other",
+	plural.Zero:  "zero",
+	plural.One:   "one",
+	plural.Two:   "two",
+	plural.Few:   "few",
+	plural.Many:  "many",
+}
+
+// relativeTime formats a time.Time relative to now, or a time.Duration from now, in the largest whole unit.
+func (lf localeFormats) relativeTime(tag language.Tag, v any) (string, error) {
+	now := time.Now()
+	switch v := v.(type) {
+	case time.Time:
+		return lf.relativeTimeAt(tag, now, v), nil
+	case time.Duration:
+		return lf.relativeTimeAt(tag, now, now.Add(v)), nil
+	}
+	return "", fmt.Errorf("relativeTime: %T is not a time.Time or time.Duration", v)
+}
+
+// relativeTimeAt formats t relative to now in the largest whole unit.
+// Years and months are whole calendar months between both dates in the location of t,
+// e.g. from January 31 to February 28 is a month, but from January 28 to February 27 isn't.
+func (lf localeFormats) relativeTimeAt(tag language.Tag, now, t time.Time) string {
+	d := t.Sub(now)
+	future := 0
+	if d > 0 {
+		future = 1
+	}
+	months := calendarMonths(now.In(t.Location()), t)
+	for _, u := range relativeUnits {
+		var n int
+		if u.months > 0 {
+			n = months / u.months
+		} else {
+			n = int(d.Abs() / u.d)
+		}
+		if n == 0 {
+			continue
+		}
+		forms := lf.relative[u.name][future]
+		pattern, ok := forms[pluralForms[plural.Cardinal.MatchPlural(tag, n, 0, 0, 0, 0)]]
+		if !ok {
+			pattern = forms["other"]
+		}
+		return strings.Replace(pattern, "{0}", message.NewPrinter(tag).Sprint(number.Decimal(n)), 1)
+	}
+	return lf.now
+}
+
+// calendarMonths returns the number of whole calendar months between a and b, in either order.
+func calendarMonths(a, b time.Time) int {
+	if b.Before(a) {
+		a, b = b, a
+	}
+	months := (b.Year()-a.Year())*12 + int(b.Month()-a.Month())
+	if months > 0 && addMonths(a, months).After(b) {
+		months--
+	}
+	return months
+}
+
+// addMonths adds months to t, clamping the day to the last one of the resulting month.
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	day := min(t.Day(), first.AddDate(0, 1, -1).Day())
+	return first.AddDate(0, 0, day-1)
+}
+
+// money is an exact amount in the minor unit of its currency, such as i18nrt.Money.
+type money interface {
+	MinorUnits() int64
+	CurrencyCode() string
+}
+
+// formatCurrency formats an amount of a currency rounded to its ISO 4217 minor unit.
+// Amounts implementing money, such as i18nrt.Money, carry their currency, and other numbers
+// are in the currency of ISO 4217 code.
+func (lf localeFormats) formatCurrency(tag language.Tag, amount any, code ...any) (string, error) {
+	m, isMoney := amount.(money)
+	switch {
+	case isMoney && len(code) > 0:
+		return "", fmt.Errorf("currency: %T carries its currency", amount)
+	case isMoney:
+		code = []any{m.CurrencyCode()}
+	case len(code) != 1:
+		return "", fmt.Errorf("currency: want a currency code for %T", amount)
+	}
+	unit, err := currency.ParseISO(fmt.Sprint(code[0]))
+	if err != nil {
+		return "", fmt.Errorf("currency: %w", err)
+	}
+	scale, ok := minorUnits(unit)
+	if !ok {
+		return "", fmt.Errorf("currency: %s has no minor unit", unit)
+	}
+	var s string
+	if isMoney {
+		s = lf.formatMinorUnits(tag, m.MinorUnits(), scale)
+	} else if s, err = formatNumber(tag, "currency", number.Decimal(amount, number.Scale(scale)), amount); err != nil {
+		return "", err
+	}
+
+	sign := ""
+	if abs, ok := strings.CutPrefix(s, "-"); ok {
+		sign, s = "-", abs
+	}
+	symbol := message.NewPrinter(tag).Sprint(currency.Symbol(unit))
+	return sign + strings.NewReplacer("¤", symbol, "#", s).Replace(lf.currency), nil
+}
+
+// isoMinorUnits holds the ISO 4217 minor units of currencies with other than 2, by code,
+// -1 for those without minor units such as gold.
+var isoMinorUnits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+	"XAG": -1, "XAU": -1, "XBA": -1, "XBB": -1, "XBC": -1, "XBD": -1, "XDR": -1,
+	"XPD": -1, "XPT": -1, "XSU": -1, "XTS": -1, "XUA": -1, "XXX": -1,
+}
+
+// minorUnits returns the number of decimal digits of the ISO 4217 minor unit of unit,
+// if it has one.
+func minorUnits(unit currency.Unit) (int, bool) {
+	digits, ok := isoMinorUnits[unit.String()]
+	if !ok {
+		return 2, true
+	}
+	return digits, digits >= 0
+}
+
+// formatMinorUnits formats an amount in minor units with scale decimals by the rules of tag.
+// Integer and fractional parts are formatted separately, since float64 can't hold every int64 exactly.
+func (lf localeFormats) formatMinorUnits(tag language.Tag, minor int64, scale int) string {
+	sign, abs := "", uint64(minor)
+	if minor < 0 {
+		sign, abs = "-", -abs
+	}
+	pow := uint64(1)
+	for range scale {
+		pow *= 10
+	}
+	s := sign + message.NewPrinter(tag).Sprint(number.Decimal(abs/pow))
+	if scale > 0 {
+		s += lf.decimal + fmt.Sprintf("%0*d", scale, abs%pow)
+	}
+	return s
+}
+
+// joinFunc joins the elements of a slice or array with sep.
+func joinFunc(sep string, items any) (string, error) {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", fmt.Errorf("join: %T is not a slice", items)
+	}
+	elems := make([]string, v.Len())
+	for i := range elems {
+		elems[i] = fmt.Sprint(v.Index(i).Interface())
+	}
+	return strings.Join(elems, sep), nil
+}
+
+// truncateFunc shortens text to n user-perceived characters followed by an ellipsis,
+// never splitting Unicode extended grapheme clusters.
+func truncateFunc(n int, v any) string {
+	s := fmt.Sprint(v)
+	rest, state := s, -1
+	for ; n > 0 && rest != ""; n-- {
+		_, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+	}
+	if rest == "" {
+		return s
+	}
+	return s[:len(s)-len(rest)] + "…"
+}
+
+// defaultFunc returns v, or dft if v is empty.
+func defaultFunc(dft, v any) any {
+	if v == nil {
+		return dft
+	}
+	if rv := reflect.ValueOf(v); rv.IsZero() || (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map) && rv.Len() == 0 {
+		return dft
+	}
+	return v
+}
+
+// enFuncs holds template functions formatting values by en rules.
+var enFuncs = templateFuncs(language.MustParse("en"), localeFormats{
+	date:        [3]string{"M/d/yy", "MMM d, y", "MMMM d, y"},
+	time:        [3]string{"h:mm a", "h:mm:ss a", "h:mm:ss a z"},
+	dateTime:    [3]string{"{1}, {0}", "{1}, {0}", "{1} 'at' {0}"},
+	months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	shortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	dayPeriods:  [2]string{"AM", "PM"},
+	now:         "now",
+	relative: map[string][2]map[string]string{
+		"year":   {{"one": "{0} year ago", "other": "{0} years ago"}, {"one": "in {0} year", "other": "in {0} years"}},
+		"month":  {{"one": "{0} month ago", "other": "{0} months ago"}, {"one": "in {0} month", "other": "in {0} months"}},
+		"week":   {{"one": "{0} week ago", "other": "{0} weeks ago"}, {"one": "in {0} week", "other": "in {0} weeks"}},
+		"day":    {{"one": "{0} day ago", "other": "{0} days ago"}, {"one": "in {0} day", "other": "in {0} days"}},
+		"hour":   {{"one": "{0} hour ago", "other": "{0} hours ago"}, {"one": "in {0} hour", "other": "in {0} hours"}},
+		"minute": {{"one": "{0} minute ago", "other": "{0} minutes ago"}, {"one": "in {0} minute", "other": "in {0} minutes"}},
+		"second": {{"one": "{0} second ago", "other": "{0} seconds ago"}, {"one": "in {0} second", "other": "in {0} seconds"}},
+	},
+	currency: "¤#",
+	decimal:  ".",
+})
+
+type en struct {
+	AppointmentDft   *template.Template
+	CityBannerDft    *template.Template
+	DueDateDft       *template.Template
+	GreetingDft      *template.Template
+	GreetingCustom0  *template.Template
+	InvoiceTotalDft  *template.Template
+	LastLoginDft     *template.Template
+	SessionExpiryDft *template.Template
+	StatsDft         *template.Template
+	SummaryDft       *template.Template
+	TagListDft       *template.Template
+}
+
+func newEn() *en {
+	return &en{
+		AppointmentDft:   template.Must(template.New("Appointment").Funcs(enFuncs).Parse("Your appointment: {{ datetime .At \"long\" }}.")),
+		CityBannerDft:    template.Must(template.New("CityBanner").Funcs(enFuncs).Parse("Welcome to {{ upper .City }}!")),
+		DueDateDft:       template.Must(template.New("DueDate").Funcs(enFuncs).Parse("Due on {{ date .Due }} at {{ time .Due \"short\" }}.")),
+		GreetingDft:      template.Must(template.New("Greeting").Funcs(enFuncs).Parse("Hello {{ default \"guest\" .Name | title }}! You have {{ .Count }} messages.")),
+		GreetingCustom0:  template.Must(template.New("GreetingCustom0").Funcs(enFuncs).Parse("Hello {{ default \"guest\" .Name | title }}! You have no messages.")),
+		InvoiceTotalDft:  template.Must(template.New("InvoiceTotal").Funcs(enFuncs).Parse("Total due: {{ currency .Amount }}, including {{ currency .Tax .Code }} VAT.")),
+		LastLoginDft:     template.Must(template.New("LastLogin").Funcs(enFuncs).Parse("Last login: {{ relativeTime .At }}.")),
+		SessionExpiryDft: template.Must(template.New("SessionExpiry").Funcs(enFuncs).Parse("Your session expires {{ relativeTime .Left }}.")),
+		StatsDft:         template.Must(template.New("Stats").Funcs(enFuncs).Parse("{{ number .Users }} users, {{ percent .Active }} active, {{ decimal .Score 2 }} points on average.")),
+		SummaryDft:       template.Must(template.New("Summary").Funcs(enFuncs).Parse("{{ truncate 12 .Text }}")),
+		TagListDft:       template.Must(template.New("TagList").Funcs(enFuncs).Parse("Tags: {{ join \", \" .Tags | lower }}.")),
+	}
+}
+
+// Appointment renders a properly translated message.
+func (t *en) Appointment(args AppointmentArgs) (string, error) {
+	data := args
+	var tmpl *template.Template
+	tmpl = t.AppointmentDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// CityBanner renders a properly translated message.
+func (t *en) CityBanner(args CityBannerArgs) (string, error) {
+	data := args
+	var tmpl *template.Template
+	tmpl = t.CityBannerDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// DueDate renders a properly translated message.
+func (t *en) DueDate(args DueDateArgs) (string, error) {
+	data := args
+	var tmpl *template.Template
+	tmpl = t.DueDateDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Greeting renders a properly translated message.
+func (t *en) Greeting(args GreetingArgs) (string, error) {
+	count := args.Count
+	data := args
+	var tmpl *template.Template
+	switch {
+	case count == 0:
+		tmpl = t.GreetingCustom0
+	default:
+		tmpl = t.GreetingDft
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// InvoiceTotal renders a properly translated message.
+func (t *en) InvoiceTotal(args InvoiceTotalArgs) (string, error) {
+	data := args
+	var tmpl *template.Template
+	tmpl = t.InvoiceTotalDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// LastLogin renders a properly translated message.
+func (t *en) LastLogin(args LastLoginArgs) (string, error) {
+	data := args
+	var tmpl *template.Template
+	tmpl = t.LastLoginDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// SessionExpiry renders a properly translated message.
+func (t *en) SessionExpiry(args SessionExpiryArgs) (string, error) {
+	data := args
+	var tmpl *template.Template
+	tmpl = t.SessionExpiryDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Stats renders a properly translated message.
+func (t *en) Stats(args StatsArgs) (string, error) {
+	data := args
+	var tmpl *template.Template
+	tmpl = t.StatsDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Summary renders a properly translated message.
+func (t *en) Summary(args SummaryArgs) (string, error) {
+	data := args
+	var tmpl *template.Template
+	tmpl = t.SummaryDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// TagList renders a properly translated message.
+func (t *en) TagList(args TagListArgs) (string, error) {
+	data := args
+	var tmpl *template.Template
+	tmpl = t.TagListDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Total renders a properly translated message.
+func (t *en) Total(args TotalArgs) (string, error) {
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(args.Count))
+	b.WriteString(" items")
+	return b.String(), nil
+}
+
+// trFuncs holds template functions formatting values by tr rules.
+var trFuncs = templateFuncs(language.MustParse("tr"), localeFormats{
+	date:        [3]string{"d.MM.y", "d MMM y", "d MMMM y"},
+	time:        [3]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z"},
+	dateTime:    [3]string{"{1} {0}", "{1} {0}", "{1} {0}"},
+	months:      [12]string{"Ocak", "Şubat", "Mart", "Nisan", "Mayıs", "Haziran", "Temmuz", "Ağustos", "Eylül", "Ekim", "Kasım", "Aralık"},
+	shortMonths: [12]string{"Oca", "Şub", "Mar", "Nis", "May", "Haz", "Tem", "Ağu", "Eyl", "Eki", "Kas", "Ara"},
+	dayPeriods:  [2]string{"ÖÖ", "ÖS"},
+	now:         "şimdi",
+	relative: map[string][2]map[string]string{
+		"year":   {{"other": "{0} yıl önce"}, {"other": "{0} yıl sonra"}},
+		"month":  {{"other": "{0} ay önce"}, {"other": "{0} ay sonra"}},
+		"week":   {{"other": "{0} hafta önce"}, {"other": "{0} hafta sonra"}},
+		"day":    {{"other": "{0} gün önce"}, {"other": "{0} gün sonra"}},
+		"hour":   {{"other": "{0} saat önce"}, {"other": "{0} saat sonra"}},
+		"minute": {{"other": "{0} dakika önce"}, {"other": "{0} dakika sonra"}},
+		"second": {{"other": "{0} saniye önce"}, {"other": "{0} saniye sonra"}},
+	},
+	currency: "¤#",
+	decimal:  ",",
+})
+
+type tr struct {
+	AppointmentDft   *template.Template
+	CityBannerDft    *template.Template
+	DueDateDft       *template.Template
+	GreetingDft      *template.Template
+	GreetingCustom0  *template.Template
+	InvoiceTotalDft  *template.Template
+	LastLoginDft     *template.Template
+	SessionExpiryDft *template.Template
+	StatsDft         *template.Template
+	SummaryDft       *template.Template
+	TagListDft       *template.Template
+}
+
+func newTr() *tr {
+	return &tr{
+		AppointmentDft:   template.Must(template.New("Appointment").Funcs(trFuncs).Parse("Randevunuz: {{ datetime .At \"long\" }}.")),
+		CityBannerDft:    template.Must(template.New("CityBanner").Funcs(trFuncs).Parse("{{ upper .City }}'a hoş geldiniz!")),
+		DueDateDft:       template.Must(template.New("DueDate").Funcs(trFuncs).Parse("Son tarih {{ date .Due }} {{ time .Due \"short\" }}.")),
+		GreetingDft:      template.Must(template.New("Greeting").Funcs(trFuncs).Parse("Merhaba {{ default \"misafir\" .Name | title }}! {{ .Count }} mesajınız var.")),
+		GreetingCustom0:  template.Must(template.New("GreetingCustom0").Funcs(trFuncs).Parse("Merhaba {{ default \"misafir\" .Name | title }}! Hiç mesajınız yok.")),
+		InvoiceTotalDft:  template.Must(template.New("InvoiceTotal").Funcs(trFuncs).Parse("Ödenecek tutar: {{ currency .Amount }}, {{ currency .Tax .Code }} KDV dahil.")),
+		LastLoginDft:     template.Must(template.New("LastLogin").Funcs(trFuncs).Parse("Son giriş: {{ relativeTime .At }}.")),
+		SessionExpiryDft: template.Must(template.New("SessionExpiry").Funcs(trFuncs).Parse("Oturumunuz {{ relativeTime .Left }} sona erecek.")),
+		StatsDft:         template.Must(template.New("Stats").Funcs(trFuncs).Parse("{{ number .Users }} kullanıcı, {{ percent .Active }} aktif, ortalama {{ decimal .Score 2 }} puan.")),
+		SummaryDft:       template.Must(template.New("Summary").Funcs(trFuncs).Parse("{{ truncate 12 .Text }}")),
+		TagListDft:       template.Must(template.New("TagList").Funcs(trFuncs).Parse("Etiketler: {{ join \", \" .Tags | lower }}.")),
+	}
+}
+
+// Appointment renders a properly translated message.
+func (t *tr) Appointment(args AppointmentArgs) (string, error) {
+	data := args
+	var tmpl *template.Template
+	tmpl = t.AppointmentDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// CityBanner renders a properly translated message.
+func (t *tr) CityBanner(args CityBannerArgs) (string, error) {
+	data := args
+	var tmpl *template.Template
+	tmpl = t.CityBannerDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// DueDate renders a properly translated message.
+func (t *tr) DueDate(args DueDateArgs) (string, error) {
+	data := args
+	var tmpl *template.Template
+	tmpl = t.DueDateDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Greeting renders a properly translated message.
+func (t *tr) Greeting(args GreetingArgs) (string, error) {
+	count := args.Count
+	data := args
+	var tmpl *template.Template
+	switch {
+	case count == 0:
+		tmpl = t.GreetingCustom0
+	default:
+		tmpl = t.GreetingDft
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// InvoiceTotal renders a properly translated message.
+func (t *tr) InvoiceTotal(args InvoiceTotalArgs) (string, error) {
+	data := args
+	var tmpl *template.Template
+	tmpl = t.InvoiceTotalDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// LastLogin renders a properly translated message.
+func (t *tr) LastLogin(args LastLoginArgs) (string, error) {
+	data := args
+	var tmpl *template.Template
+	tmpl = t.LastLoginDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// SessionExpiry renders a properly translated message.
+func (t *tr) SessionExpiry(args SessionExpiryArgs) (string, error) {
+	data := args
+	var tmpl *template.Template
+	tmpl = t.SessionExpiryDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Stats renders a properly translated message.
+func (t *tr) Stats(args StatsArgs) (string, error) {
+	data := args
+	var tmpl *template.Template
+	tmpl = t.StatsDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Summary renders a properly translated message.
+func (t *tr) Summary(args SummaryArgs) (string, error) {
+	data := args
+	var tmpl *template.Template
+	tmpl = t.SummaryDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// TagList renders a properly translated message.
+func (t *tr) TagList(args TagListArgs) (string, error) {
+	data := args
+	var tmpl *template.Template
+	tmpl = t.TagListDft
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Total renders a properly translated message.
+func (t *tr) Total(args TotalArgs) (string, error) {
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(args.Count))
+	b.WriteString(" öğe")
+	return b.String(), nil
+}
+
+// NewDevTranslators initializes translators reading translation files in fsys at runtime,
+// parsing templates again whenever the files change, for local development.
+// Only message text is live: regenerate after changing variables or custom template expressions.
+func NewDevTranslators(fsys fs.FS, opts ...i18ndev.Option) map[Lang]Translator {
+	source := i18ndev.NewSource(fsys, ".", opts...)
+	return map[Lang]Translator{
+		LangEn: &devEn{source: source},
+		LangTr: &devTr{source: source},
+	}
+}
+
+type devEn struct {
+	source *i18ndev.Source
+}
+
+// Appointment renders a translated message from the current translation files.
+func (t *devEn) Appointment(args AppointmentArgs) (string, error) {
+	data := args
+	tmpl, err := t.source.Template("en", "appointment", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// CityBanner renders a translated message from the current translation files.
+func (t *devEn) CityBanner(args CityBannerArgs) (string, error) {
+	data := args
+	tmpl, err := t.source.Template("en", "city_banner", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// DueDate renders a translated message from the current translation files.
+func (t *devEn) DueDate(args DueDateArgs) (string, error) {
+	data := args
+	tmpl, err := t.source.Template("en", "due_date", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Greeting renders a translated message from the current translation files.
+func (t *devEn) Greeting(args GreetingArgs) (string, error) {
+	count := args.Count
+	data := args
+	condition := ""
+	switch {
+	case count == 0:
+		condition = "count == 0"
+	}
+	tmpl, err := t.source.Template("en", "greeting", condition, "count == 0")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// InvoiceTotal renders a translated message from the current translation files.
+func (t *devEn) InvoiceTotal(args InvoiceTotalArgs) (string, error) {
+	data := args
+	tmpl, err := t.source.Template("en", "invoice_total", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// LastLogin renders a translated message from the current translation files.
+func (t *devEn) LastLogin(args LastLoginArgs) (string, error) {
+	data := args
+	tmpl, err := t.source.Template("en", "last_login", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// SessionExpiry renders a translated message from the current translation files.
+func (t *devEn) SessionExpiry(args SessionExpiryArgs) (string, error) {
+	data := args
+	tmpl, err := t.source.Template("en", "session_expiry", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Stats renders a translated message from the current translation files.
+func (t *devEn) Stats(args StatsArgs) (string, error) {
+	data := args
+	tmpl, err := t.source.Template("en", "stats", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Summary renders a translated message from the current translation files.
+func (t *devEn) Summary(args SummaryArgs) (string, error) {
+	data := args
+	tmpl, err := t.source.Template("en", "summary", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// TagList renders a translated message from the current translation files.
+func (t *devEn) TagList(args TagListArgs) (string, error) {
+	data := args
+	tmpl, err := t.source.Template("en", "tag_list", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Total renders a translated message from the current translation files.
+func (t *devEn) Total(args TotalArgs) (string, error) {
+	data := args
+	tmpl, err := t.source.Template("en", "total", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+type devTr struct {
+	source *i18ndev.Source
+}
+
+// Appointment renders a translated message from the current translation files.
+func (t *devTr) Appointment(args AppointmentArgs) (string, error) {
+	data := args
+	tmpl, err := t.source.Template("tr", "appointment", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// CityBanner renders a translated message from the current translation files.
+func (t *devTr) CityBanner(args CityBannerArgs) (string, error) {
+	data := args
+	tmpl, err := t.source.Template("tr", "city_banner", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// DueDate renders a translated message from the current translation files.
+func (t *devTr) DueDate(args DueDateArgs) (string, error) {
+	data := args
+	tmpl, err := t.source.Template("tr", "due_date", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Greeting renders a translated message from the current translation files.
+func (t *devTr) Greeting(args GreetingArgs) (string, error) {
+	count := args.Count
+	data := args
+	condition := ""
+	switch {
+	case count == 0:
+		condition = "count == 0"
+	}
+	tmpl, err := t.source.Template("tr", "greeting", condition, "count == 0")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// InvoiceTotal renders a translated message from the current translation files.
+func (t *devTr) InvoiceTotal(args InvoiceTotalArgs) (string, error) {
+	data := args
+	tmpl, err := t.source.Template("tr", "invoice_total", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// LastLogin renders a translated message from the current translation files.
+func (t *devTr) LastLogin(args LastLoginArgs) (string, error) {
+	data := args
+	tmpl, err := t.source.Template("tr", "last_login", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// SessionExpiry renders a translated message from the current translation files.
+func (t *devTr) SessionExpiry(args SessionExpiryArgs) (string, error) {
+	data := args
+	tmpl, err := t.source.Template("tr", "session_expiry", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Stats renders a translated message from the current translation files.
+func (t *devTr) Stats(args StatsArgs) (string, error) {
+	data := args
+	tmpl, err := t.source.Template("tr", "stats", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Summary renders a translated message from the current translation files.
+func (t *devTr) Summary(args SummaryArgs) (string, error) {
+	data := args
+	tmpl, err := t.source.Template("tr", "summary", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// TagList renders a translated message from the current translation files.
+func (t *devTr) TagList(args TagListArgs) (string, error) {
+	data := args
+	tmpl, err := t.source.Template("tr", "tag_list", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Total renders a translated message from the current translation files.
+func (t *devTr) Total(args TotalArgs) (string, error) {
+	data := args
+	tmpl, err := t.source.Template("tr", "total", "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,108 @@
+// Code generated by i18ngo. DO NOT EDIT.
+package translations
+
+import (
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	t.Parallel()
+
+	tt := NewTranslators()
+	tests := []struct {
+		name   string
+		lang   Lang
+		render func(Translator) (string, error)
+		want   string
+	}{
+		{
+			name:   "en/city_banner/0",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.CityBanner(CityBannerArgs{City: "istanbul"}) },
+			want:   "Welcome to ISTANBUL!",
+		},
+		{
+			name:   "en/greeting/0",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.Greeting(GreetingArgs{Count: 0, Name: ""}) },
+			want:   "Hello Guest! You have no messages.",
+		},
+		{
+			name:   "en/greeting/1",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.Greeting(GreetingArgs{Count: 2, Name: "ann lee"}) },
+			want:   "Hello Ann Lee! You have 2 messages.",
+		},
+		{
+			name: "en/stats/0",
+			lang: LangEn,
+			render: func(tr Translator) (string, error) {
+				return tr.Stats(StatsArgs{Active: 0.256, Score: 1234.5, Users: 1234567})
+			},
+			want: "1,234,567 users, 26% active, 1,234.50 points on average.",
+		},
+		{
+			name:   "en/summary/0",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.Summary(SummaryArgs{Text: "Hello, world!"}) },
+			want:   "Hello, world…",
+		},
+		{
+			name:   "en/summary/1",
+			lang:   LangEn,
+			render: func(tr Translator) (string, error) { return tr.Summary(SummaryArgs{Text: "Short"}) },
+			want:   "Short",
+		},
+		{
+			name:   "tr/city_banner/0",
+			lang:   LangTr,
+			render: func(tr Translator) (string, error) { return tr.CityBanner(CityBannerArgs{City: "istanbul"}) },
+			want:   "İSTANBUL'a hoş geldiniz!",
+		},
+		{
+			name:   "tr/greeting/0",
+			lang:   LangTr,
+			render: func(tr Translator) (string, error) { return tr.Greeting(GreetingArgs{Count: 0, Name: ""}) },
+			want:   "Merhaba Misafir! Hiç mesajınız yok.",
+		},
+		{
+			name:   "tr/greeting/1",
+			lang:   LangTr,
+			render: func(tr Translator) (string, error) { return tr.Greeting(GreetingArgs{Count: 2, Name: "ilkay"}) },
+			want:   "Merhaba İlkay! 2 mesajınız var.",
+		},
+		{
+			name: "tr/stats/0",
+			lang: LangTr,
+			render: func(tr Translator) (string, error) {
+				return tr.Stats(StatsArgs{Active: 0.256, Score: 1234.5, Users: 1234567})
+			},
+			want: "1.234.567 kullanıcı, %26 aktif, ortalama 1.234,50 puan.",
+		},
+		{
+			name: "tr/summary/0",
+			lang: LangTr,
+			render: func(tr Translator) (string, error) {
+				return tr.Summary(SummaryArgs{Text: "🇹🇷 Türkiye'de yaşamak"})
+			},
+			want: "🇹🇷 Türkiye&#39;de…",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tr, ok := tt[tc.lang]
+			if !ok {
+				t.Skipf("%s is not compiled in", tc.lang)
+			}
+			got, err := tc.render(tr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
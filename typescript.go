@@ -0,0 +1,600 @@
+package i18ngo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/danicc097/i18ngo/templates"
+	"github.com/kenshaw/snaker"
+	"golang.org/x/text/language"
+)
+
+// Target is the language code is generated in.
+type Target string
+
+const (
+	TargetGo         Target = "go"
+	TargetTypeScript Target = "ts"
+)
+
+// checkTarget returns an error if target is not TargetGo or TargetTypeScript.
+// The empty target is TargetGo.
+func checkTarget(target Target) error {
+	switch target {
+	case "", TargetGo, TargetTypeScript:
+		return nil
+	}
+
+	return fmt.Errorf("unknown target %q, want %s or %s", target, TargetGo, TargetTypeScript)
+}
+
+// WithTarget sets the language Generate writes code for. It defaults to TargetGo.
+func WithTarget(target Target) GenerateOption {
+	return func(opts *generateOptions) {
+		opts.Target = target
+	}
+}
+
+type tsData struct {
+	*templates.TemplateData
+	Messages     []tsMessage
+	Translations []tsTranslation
+}
+
+type tsTranslation struct {
+	Lang string
+	// Name is the name of the translator constant.
+	Name     string
+	Messages []tsMessage
+}
+
+type tsMessage struct {
+	Name string
+	// Params is the parameter list of the method.
+	Params   string
+	ArgsType string
+	Vars     []tsVar
+	// Body holds the method statements, indented for a method of an object literal.
+	Body string
+}
+
+type tsVar struct {
+	Name string
+	Type string
+}
+
+// generateTypeScript generates a TypeScript module with a translator per language
+// implementing the same messages as the generated Go translators.
+func generateTypeScript(data *templates.TemplateData) ([]byte, error) {
+	ts := tsData{TemplateData: data}
+	var errs []error
+	for _, tr := range data.Translations {
+		tsTr := tsTranslation{
+			Lang: tr.Lang,
+			Name: "translator" + snaker.ForceCamelIdentifier(tr.CamelLang),
+		}
+		for _, msg := range tr.Messages {
+			tsMsg, err := newTSMessage(tr.Lang, msg)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("message %q in %s: %w", msg.ID, tr.Lang, err))
+				continue
+			}
+			tsTr.Messages = append(tsTr.Messages, tsMsg)
+		}
+		ts.Translations = append(ts.Translations, tsTr)
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("unsupported by the TypeScript target: %w", errors.Join(errs...))
+	}
+	ts.Messages = ts.Translations[0].Messages // all translations have the same messages
+
+	tmpl := template.Must(template.New("typescript.ts.tpl").Funcs(template.FuncMap{
+		"quote": tsQuote,
+	}).ParseFS(templateFS, "templates/typescript.ts.tpl"))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, ts); err != nil {
+		return nil, fmt.Errorf("error executing template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func newTSMessage(lang string, msg templates.MessageData) (tsMessage, error) {
+	tsMsg := tsMessage{
+		Name:     snaker.ForceLowerCamelIdentifier(msg.MethodName),
+		ArgsType: msg.ArgsType,
+	}
+
+	vars := make(map[string]templates.VarData, len(msg.Vars))
+	params := make([]string, 0, len(msg.Vars))
+	for _, v := range msg.Vars {
+		typ, ok := tsType(v.Type)
+		if !ok {
+			return tsMessage{}, fmt.Errorf("variable %s has unsupported type %s", v.Name, v.Type)
+		}
+		vars[v.Name] = v
+		tsMsg.Vars = append(tsMsg.Vars, tsVar{Name: v.Name, Type: typ})
+		params = append(params, v.Param+": "+typ)
+	}
+	tsMsg.Params = strings.Join(params, ", ")
+	if msg.ArgsType != "" {
+		tsMsg.Params = msg.CallArgs + ": " + msg.ArgsType
+	}
+
+	var b strings.Builder
+	for _, ct := range msg.CustomTemplates {
+		cond, err := tsCondition(lang, ct, vars)
+		if err != nil {
+			return tsMessage{}, fmt.Errorf("custom template expression %q: %w", ct.Expression, err)
+		}
+		body, err := tsTemplate(ct.Template, vars, 3)
+		if err != nil {
+			return tsMessage{}, fmt.Errorf("custom template %q: %w", ct.Template, err)
+		}
+		fmt.Fprintf(&b, "    if (%s) {\n%s    }\n", cond, body)
+	}
+	body, err := tsTemplate(msg.Template, vars, 2)
+	if err != nil {
+		return tsMessage{}, fmt.Errorf("template %q: %w", msg.Template, err)
+	}
+	b.WriteString(body)
+	tsMsg.Body = strings.TrimSuffix(b.String(), "\n")
+
+	return tsMsg, nil
+}
+
+// tsType returns the TypeScript type of a Go variable type.
+func tsType(typ string) (string, bool) {
+	switch typ {
+	case "string":
+		return "string", true
+	case "bool":
+		return "boolean", true
+	case "int", "int8", "int16", "int32", "int64", "rune",
+		"uint", "uint8", "uint16", "uint32", "uint64", "byte",
+		"float32", "float64":
+		return "number", true
+	case "interface{}", "any":
+		return "unknown", true
+	}
+
+	return "", false
+}
+
+// tsQuote returns s as a string literal, which JSON strings are valid as.
+func tsQuote(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s) // never fails for strings
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// tsTemplateCompiler translates the template subset supported by compileTemplate into TypeScript statements
+// building a string named s, escaped the same way html/template would.
+type tsTemplateCompiler struct {
+	vars  map[string]templates.VarData
+	buf   strings.Builder
+	depth int
+	// inTag reports whether the text written so far ends inside an HTML tag,
+	// where html/template escapes values differently.
+	inTag bool
+}
+
+// tsTemplate returns TypeScript statements returning the rendered template, indented by depth levels.
+func tsTemplate(tpl string, vars map[string]templates.VarData, depth int) (string, error) {
+	tree := parse.New("")
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse(tpl, "", "", map[string]*parse.Tree{}); err != nil {
+		return "", err
+	}
+
+	c := &tsTemplateCompiler{vars: vars, depth: depth}
+	c.line(`let s = "";`)
+	if err := c.list(tree.Root); err != nil {
+		return "", err
+	}
+	c.line("return s;")
+
+	return c.buf.String(), nil
+}
+
+func (c *tsTemplateCompiler) line(format string, args ...any) {
+	c.buf.WriteString(strings.Repeat("  ", c.depth))
+	fmt.Fprintf(&c.buf, format, args...)
+	c.buf.WriteString("\n")
+}
+
+func (c *tsTemplateCompiler) list(list *parse.ListNode) error {
+	if list == nil {
+		return nil
+	}
+	for _, node := range list.Nodes {
+		if err := c.node(node); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *tsTemplateCompiler) node(node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.TextNode:
+		text := string(n.Text)
+		lower := strings.ToLower(text)
+		if strings.Contains(lower, "<script") || strings.Contains(lower, "<style") || strings.Contains(text, "<!--") {
+			return fmt.Errorf("unsupported HTML in %q", text)
+		}
+		if i := strings.LastIndexAny(text, "<>"); i != -1 {
+			c.inTag = text[i] == '<'
+		}
+		c.line("s += %s;", tsQuote(text))
+	case *parse.ActionNode:
+		if len(n.Pipe.Decl) > 0 || len(n.Pipe.Cmds) != 1 {
+			return fmt.Errorf("unsupported action %s", n)
+		}
+		if c.inTag {
+			return fmt.Errorf("unsupported action %s inside an HTML tag", n)
+		}
+		expr, err := c.command(n.Pipe.Cmds[0])
+		if err != nil {
+			return err
+		}
+		c.line("s += %s;", expr)
+	case *parse.IfNode:
+		cond, err := c.condition(n.Pipe)
+		if err != nil {
+			return err
+		}
+		c.line("if (%s) {", cond)
+		c.depth++
+		if err := c.list(n.List); err != nil {
+			return err
+		}
+		c.depth--
+		if n.ElseList != nil {
+			c.line("} else {")
+			c.depth++
+			if err := c.list(n.ElseList); err != nil {
+				return err
+			}
+			c.depth--
+		}
+		c.line("}")
+	default:
+		return fmt.Errorf("unsupported template construct %s", n)
+	}
+
+	return nil
+}
+
+// command returns an escaped string expression for a field or printf call.
+func (c *tsTemplateCompiler) command(cmd *parse.CommandNode) (string, error) {
+	switch arg := cmd.Args[0].(type) {
+	case *parse.FieldNode:
+		v, err := c.field(arg)
+		if err != nil {
+			return "", err
+		}
+		if len(cmd.Args) != 1 {
+			return "", fmt.Errorf("unsupported command %s", cmd)
+		}
+		return "escapeHTML(" + tsFormat(v) + ")", nil
+	case *parse.IdentifierNode:
+		if arg.Ident != "printf" || len(cmd.Args) < 2 {
+			break
+		}
+		format, ok := cmd.Args[1].(*parse.StringNode)
+		if !ok {
+			break
+		}
+		expr, err := c.printf(format.Text, cmd.Args[2:])
+		if err != nil {
+			return "", err
+		}
+		return "escapeHTML(" + expr + ")", nil
+	}
+
+	return "", fmt.Errorf("unsupported command %s", cmd)
+}
+
+// printf returns a string expression formatting args with the %d, %s and %v verbs.
+func (c *tsTemplateCompiler) printf(format string, args []parse.Node) (string, error) {
+	var parts []string
+	var text strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			text.WriteByte(format[i])
+			continue
+		}
+		i++
+		if i == len(format) {
+			return "", fmt.Errorf("unsupported printf format %q", format)
+		}
+		verb := format[i]
+		if verb == '%' {
+			text.WriteByte('%')
+			continue
+		}
+		if verb != 'd' && verb != 's' && verb != 'v' || len(args) == 0 {
+			return "", fmt.Errorf("unsupported printf format %q", format)
+		}
+		if text.Len() > 0 {
+			parts = append(parts, tsQuote(text.String()))
+			text.Reset()
+		}
+		part, err := c.printfArg(args[0], verb)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, part)
+		args = args[1:]
+	}
+	if len(args) > 0 {
+		return "", fmt.Errorf("unsupported printf format %q: extra arguments", format)
+	}
+	if text.Len() > 0 || len(parts) == 0 {
+		parts = append(parts, tsQuote(text.String()))
+	}
+
+	return strings.Join(parts, " + "), nil
+}
+
+func (c *tsTemplateCompiler) printfArg(arg parse.Node, verb byte) (string, error) {
+	switch arg := arg.(type) {
+	case *parse.FieldNode:
+		v, err := c.field(arg)
+		if err != nil {
+			return "", err
+		}
+		if verb == 'd' && !isGoInteger(v.Type) || verb == 's' && v.Type != "string" {
+			return "", fmt.Errorf("unsupported printf verb %%%c for %s", verb, v.Type)
+		}
+		return tsFormat(v), nil
+	case *parse.StringNode:
+		if verb == 'd' {
+			break
+		}
+		return tsQuote(arg.Text), nil
+	case *parse.NumberNode:
+		if arg.IsInt && verb != 's' {
+			return tsQuote(strconv.FormatInt(arg.Int64, 10)), nil
+		}
+		if arg.IsFloat && verb == 'v' {
+			return tsQuote(strconv.FormatFloat(arg.Float64, 'g', -1, 64)), nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported printf argument %s", arg)
+}
+
+// condition returns a boolean expression matching template truthiness for a single field.
+func (c *tsTemplateCompiler) condition(pipe *parse.PipeNode) (string, error) {
+	if len(pipe.Decl) > 0 || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return "", fmt.Errorf("unsupported condition %s", pipe)
+	}
+	field, ok := pipe.Cmds[0].Args[0].(*parse.FieldNode)
+	if !ok {
+		return "", fmt.Errorf("unsupported condition %s", pipe)
+	}
+	v, err := c.field(field)
+	if err != nil {
+		return "", err
+	}
+
+	switch typ, _ := tsType(v.Type); typ {
+	case "boolean":
+		return v.Ref, nil
+	case "string":
+		return v.Ref + ` !== ""`, nil
+	case "number":
+		return v.Ref + " !== 0", nil
+	}
+
+	return "truthy(" + v.Ref + ")", nil
+}
+
+func (c *tsTemplateCompiler) field(f *parse.FieldNode) (templates.VarData, error) {
+	if len(f.Ident) != 1 {
+		return templates.VarData{}, fmt.Errorf("unsupported field chain %s", f)
+	}
+	v, ok := c.vars[f.Ident[0]]
+	if !ok {
+		return templates.VarData{}, fmt.Errorf("unknown field %s", f)
+	}
+
+	return v, nil
+}
+
+// tsFormat returns a string expression formatting v the same way fmt does.
+func tsFormat(v templates.VarData) string {
+	switch {
+	case v.Type == "string":
+		return v.Ref
+	case v.Type == "float32" || v.Type == "float64":
+		return "formatFloat(" + v.Ref + ")"
+	case isGoInteger(v.Type) || v.Type == "bool":
+		return "String(" + v.Ref + ")"
+	}
+
+	return "formatValue(" + v.Ref + ")"
+}
+
+func isGoInteger(typ string) bool {
+	switch typ {
+	case "int", "int8", "int16", "int32", "int64", "rune",
+		"uint", "uint8", "uint16", "uint32", "uint64", "byte":
+		return true
+	}
+
+	return false
+}
+
+// tsCondition returns the TypeScript condition selecting a custom template of lang,
+// using Intl.PluralRules for plural and ordinal forms.
+func tsCondition(lang string, ct templates.CustomTemplate, vars map[string]templates.VarData) (string, error) {
+	if ct.Plural != nil {
+		options := ""
+		if ct.Plural.Ordinal {
+			options = `, { type: "ordinal" }`
+		}
+		return fmt.Sprintf("new Intl.PluralRules(%s%s).select(%s) === %s",
+			tsQuote(language.Make(lang).String()), options, vars[ct.Plural.Variable].Ref, tsQuote(ct.Plural.Form)), nil
+	}
+
+	return tsExpression(ct.Expression, vars)
+}
+
+// tsExpression translates a custom template expression into a TypeScript condition.
+func tsExpression(expression string, vars map[string]templates.VarData) (string, error) {
+	expr, err := parser.ParseExpr(expression)
+	if err != nil {
+		return "", err
+	}
+	params := make(map[string]templates.VarData, len(vars))
+	for _, v := range vars {
+		params[v.Param] = v
+	}
+	c := &tsExpressionCompiler{vars: params}
+	ts, err := c.expr(expr)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(strings.TrimPrefix(ts, "("), ")"), nil
+}
+
+type tsExpressionCompiler struct {
+	vars map[string]templates.VarData // by parameter name
+}
+
+var tsBinaryOps = map[token.Token]string{
+	token.EQL:  "===",
+	token.NEQ:  "!==",
+	token.LSS:  "<",
+	token.LEQ:  "<=",
+	token.GTR:  ">",
+	token.GEQ:  ">=",
+	token.LAND: "&&",
+	token.LOR:  "||",
+	token.ADD:  "+",
+	token.SUB:  "-",
+	token.MUL:  "*",
+	token.REM:  "%",
+}
+
+// expr returns the TypeScript expression for e, parenthesizing binary expressions
+// since operator precedence differs between both languages.
+func (c *tsExpressionCompiler) expr(e ast.Expr) (string, error) {
+	switch e := e.(type) {
+	case *ast.ParenExpr:
+		return c.expr(e.X)
+	case *ast.Ident:
+		if v, ok := c.vars[e.Name]; ok {
+			return v.Ref, nil
+		}
+		if e.Name == "true" || e.Name == "false" {
+			return e.Name, nil
+		}
+	case *ast.BasicLit:
+		return tsLiteral(e)
+	case *ast.UnaryExpr:
+		if e.Op != token.NOT && e.Op != token.SUB && e.Op != token.ADD {
+			break
+		}
+		x, err := c.expr(e.X)
+		if err != nil {
+			return "", err
+		}
+		return e.Op.String() + x, nil
+	case *ast.BinaryExpr:
+		x, err := c.expr(e.X)
+		if err != nil {
+			return "", err
+		}
+		y, err := c.expr(e.Y)
+		if err != nil {
+			return "", err
+		}
+		if e.Op == token.QUO {
+			if c.isInteger(e.X) && c.isInteger(e.Y) {
+				return "Math.trunc(" + x + " / " + y + ")", nil
+			}
+			return "(" + x + " / " + y + ")", nil
+		}
+		op, ok := tsBinaryOps[e.Op]
+		if !ok {
+			break
+		}
+		return "(" + x + " " + op + " " + y + ")", nil
+	case *ast.CallExpr:
+		fn, ok := e.Fun.(*ast.Ident)
+		if !ok || fn.Name != "len" || len(e.Args) != 1 {
+			break
+		}
+		if v, ok := e.Args[0].(*ast.Ident); !ok || c.vars[v.Name].Type != "string" {
+			break
+		}
+		x, err := c.expr(e.Args[0])
+		if err != nil {
+			return "", err
+		}
+		return "byteLength(" + x + ")", nil
+	}
+
+	return "", fmt.Errorf("unsupported expression %s", types.ExprString(e))
+}
+
+// isInteger reports whether e is an integer expression, so that division truncates as in Go.
+func (c *tsExpressionCompiler) isInteger(e ast.Expr) bool {
+	switch e := e.(type) {
+	case *ast.ParenExpr:
+		return c.isInteger(e.X)
+	case *ast.Ident:
+		return isGoInteger(c.vars[e.Name].Type)
+	case *ast.BasicLit:
+		return e.Kind == token.INT
+	case *ast.UnaryExpr:
+		return c.isInteger(e.X)
+	case *ast.BinaryExpr:
+		return c.isInteger(e.X) && c.isInteger(e.Y)
+	case *ast.CallExpr:
+		return true // len
+	}
+
+	return false
+}
+
+func tsLiteral(lit *ast.BasicLit) (string, error) {
+	switch lit.Kind {
+	case token.INT, token.FLOAT:
+		val := constant.MakeFromLiteral(lit.Value, lit.Kind, 0)
+		if val.Kind() == constant.Int {
+			return val.ExactString(), nil
+		}
+		f, _ := constant.Float64Val(val)
+		return strconv.FormatFloat(f, 'g', -1, 64), nil
+	case token.STRING:
+		s, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return "", err
+		}
+		return tsQuote(s), nil
+	}
+
+	return "", fmt.Errorf("unsupported literal %s", lit.Value)
+}
@@ -5,9 +5,12 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"slices"
 )
 
+// ValidateCustomExpression checks expression is a valid Go expression referencing only
+// the given variables, imported package names or predeclared identifiers.
 func ValidateCustomExpression(expression string, variables []string) error {
 	fs := token.NewFileSet()
 	expr, err := parser.ParseExprFrom(fs, "", expression, 0)
@@ -19,15 +22,24 @@ func ValidateCustomExpression(expression string, variables []string) error {
 		return nil
 	}
 
-	ast.Inspect(expr, func(n ast.Node) bool {
-		if ident, ok := n.(*ast.Ident); ok {
-			if !slices.Contains(variables, ident.Name) {
-				err = fmt.Errorf("unknown variable used in expression: %s", ident.Name)
+	var inspect func(n ast.Node) bool
+	inspect = func(n ast.Node) bool {
+		if err != nil {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			ast.Inspect(n.X, inspect) // field and package member names are type-checked later
+			return false
+		case *ast.Ident:
+			if !slices.Contains(variables, n.Name) && types.Universe.Lookup(n.Name) == nil {
+				err = fmt.Errorf("unknown variable used in expression: %s", n.Name)
 				return false
 			}
 		}
 		return true
-	})
+	}
+	ast.Inspect(expr, inspect)
 
 	return err
 }
@@ -16,9 +16,12 @@ func TestValidateCustomExpression(t *testing.T) {
 		{"ValidExpression1", "count == 0", []string{"count"}, false},
 		{"ValidExpression2", "name != \"\"", []string{"name"}, false},
 		{"ValidExpression3", "age > 18 && count != 0", []string{"age", "count"}, false},
+		{"ValidSelector", "user.Gender == models.Female", []string{"user", "models"}, false},
+		{"ValidPredeclared", "len(name) > 0 && isAdmin == true", []string{"name", "isAdmin"}, false},
 
 		{"InvalidVariable1", "unknown == 0", []string{"count"}, true},
 		{"InvalidVariable2", "name != \"\" && id > 0", []string{"name"}, true},
+		{"InvalidSelector", "usr.Gender == models.Female", []string{"user", "models"}, true},
 
 		// Syntax errors (already extensively tested in std lib)
 		{"InvalidExpression", "invalid-expression(", []string{}, true},
@@ -7,7 +7,9 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/danicc097/i18ngo/templates"
 	"github.com/kenshaw/snaker"
+	"golang.org/x/text/language"
 )
 
 // ValidateTemplate checks if all variables used inside {{ .MyVar }} exist in the provided variables.
@@ -18,11 +20,11 @@ func ValidateTemplate(tpl string, variables []string) error {
 	}
 	errors := []string{}
 
-	if _, err := template.New("").Parse(tpl); err != nil {
+	if _, err := template.New("").Funcs(templates.Funcs(language.Und)).Parse(tpl); err != nil {
 		return fmt.Errorf("unparseable template: %w", err)
 	}
 
-	validRe := regexp.MustCompile(`\{\{\s*\.(\w+)\s*\}\}`)
+	validRe := regexp.MustCompile(`\{\{\s*\.(\w+)[\w\.]*\s*\}\}`)
 
 	generalRe := regexp.MustCompile(`\{\{[^}]*\}\}`)
 	generalMatches := generalRe.FindAllString(tpl, -1)
@@ -65,6 +65,17 @@ func TestValidateTemplate(t *testing.T) {
 			variables:   []string{"MyVar"},
 			errContains: "unknown variable used in template: UnknownVar1, unknown variable used in template: UnknownVar2",
 		},
+		{
+			name:      "template functions",
+			template:  `{{ upper .MyVar }}, {{ join ", " .Items | truncate 20 }} and {{ default "none" .AnotherVar }} ({{ decimal .Ratio 2 }})`,
+			variables: []string{"MyVar", "Items", "AnotherVar", "Ratio"},
+		},
+		{
+			name:        "unknown template function",
+			template:    `{{ shout .MyVar }}`,
+			variables:   []string{"MyVar"},
+			errContains: `unparseable template: template: :1: function "shout" not defined`,
+		},
 	}
 
 	for _, tt := range tests {
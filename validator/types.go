@@ -0,0 +1,375 @@
+package validator
+
+import (
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"text/template/parse"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// TypeChecker resolves variable types, including types from imported packages,
+// and type-checks custom template expressions and template field accesses.
+type TypeChecker struct {
+	fset *token.FileSet
+	pkgs map[string]*types.Package // by package name
+}
+
+// NewTypeChecker loads the given import paths through go/packages.
+// Import paths are resolved from the current working directory's module.
+func NewTypeChecker(imports []string) (*TypeChecker, error) {
+	tc := &TypeChecker{
+		fset: token.NewFileSet(),
+		pkgs: make(map[string]*types.Package),
+	}
+	if len(imports) == 0 {
+		return tc, nil
+	}
+
+	key := strings.Join(imports, "\n")
+	if cached, ok := loadedImports.Load(key); ok {
+		tc.pkgs = cached.(map[string]*types.Package)
+		return tc, nil
+	}
+
+	// Type-check from source instead of relying on export data,
+	// whose format depends on the toolchain version.
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedImports | packages.NeedDeps,
+	}
+	pkgs, err := packages.Load(cfg, imports...)
+	if err != nil {
+		return nil, fmt.Errorf("error loading imports: %w", err)
+	}
+
+	slices.SortFunc(pkgs, func(a, b *packages.Package) int { return strings.Compare(a.PkgPath, b.PkgPath) })
+	var errs []string
+	for _, pkg := range pkgs {
+		for _, e := range pkg.Errors {
+			errs = append(errs, e.Error())
+		}
+		if pkg.Types == nil {
+			continue
+		}
+		// Expressions and variable types refer to packages by name, which must be unique.
+		if other, ok := tc.pkgs[pkg.Name]; ok && other.Path() != pkg.PkgPath {
+			errs = append(errs, fmt.Sprintf("imports %q and %q have the same package name %s", other.Path(), pkg.PkgPath, pkg.Name))
+			continue
+		}
+		tc.pkgs[pkg.Name] = pkg.Types
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("error loading imports: %s", strings.Join(errs, ", "))
+	}
+	loadedImports.Store(key, tc.pkgs)
+
+	return tc, nil
+}
+
+// loadedImports caches loaded packages by import paths, since loading is slow.
+var loadedImports sync.Map
+
+// PackageNames returns the names of the loaded imported packages.
+func (tc *TypeChecker) PackageNames() []string {
+	names := make([]string, 0, len(tc.pkgs))
+	for name := range tc.pkgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// scope returns a package whose scope holds the imported package names and the given variables.
+func (tc *TypeChecker) scope(vars map[string]types.Type) *types.Package {
+	pkg := types.NewPackage("i18ngo/check", "check")
+	for name, imported := range tc.pkgs {
+		pkg.Scope().Insert(types.NewPkgName(token.NoPos, pkg, name, imported))
+	}
+	for name, typ := range vars {
+		pkg.Scope().Insert(types.NewVar(token.NoPos, pkg, name, typ))
+	}
+
+	return pkg
+}
+
+// CheckVariables resolves the declared type of each variable.
+func (tc *TypeChecker) CheckVariables(variables map[string]string) (map[string]types.Type, error) {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pkg := tc.scope(nil)
+	vars := make(map[string]types.Type, len(variables))
+	for _, name := range names {
+		tv, err := types.Eval(tc.fset, pkg, token.NoPos, variables[name])
+		if err != nil || !tv.IsType() {
+			return nil, fmt.Errorf("unknown type %q for variable %s", variables[name], name)
+		}
+		vars[name] = tv.Type
+	}
+
+	return vars, nil
+}
+
+// CheckExpression verifies a custom template expression is a valid boolean expression.
+func (tc *TypeChecker) CheckExpression(expression string, vars map[string]types.Type) error {
+	tv, err := types.Eval(tc.fset, tc.scope(vars), token.NoPos, expression)
+	if err != nil {
+		return fmt.Errorf("invalid expression: %w", err)
+	}
+	if basic, ok := tv.Type.Underlying().(*types.Basic); !ok || basic.Info()&types.IsBoolean == 0 {
+		return fmt.Errorf("expression must be boolean, got %s", tv.Type)
+	}
+
+	return nil
+}
+
+// EvalExpression evaluates a boolean expression for variables holding the given constant values.
+// ok is false if the expression does not evaluate to a constant, e.g. when calling functions.
+func (tc *TypeChecker) EvalExpression(expression string, vars map[string]types.Type, values map[string]constant.Value) (result, ok bool, err error) {
+	pkg := tc.scope(nil)
+	for name, typ := range vars {
+		if val, ok := values[name]; ok {
+			pkg.Scope().Insert(types.NewConst(token.NoPos, pkg, name, typ, val))
+		} else {
+			pkg.Scope().Insert(types.NewVar(token.NoPos, pkg, name, typ))
+		}
+	}
+
+	tv, err := types.Eval(tc.fset, pkg, token.NoPos, expression)
+	if err != nil {
+		return false, false, fmt.Errorf("invalid expression: %w", err)
+	}
+	if tv.Value == nil || tv.Value.Kind() != constant.Bool {
+		return false, false, nil
+	}
+
+	return constant.BoolVal(tv.Value), true, nil
+}
+
+// ExampleValue converts a value decoded from YAML to a constant of typ,
+// whose underlying type must be a basic type.
+func ExampleValue(typ types.Type, v any) (constant.Value, error) {
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return nil, fmt.Errorf("unsupported type %s", typ)
+	}
+
+	var val constant.Value
+	switch v := v.(type) {
+	case bool:
+		val = constant.MakeBool(v)
+	case string:
+		val = constant.MakeString(v)
+	case int:
+		val = constant.MakeInt64(int64(v))
+	case uint64:
+		val = constant.MakeUint64(v)
+	case float64:
+		val = constant.MakeFloat64(v)
+	}
+	if val == nil {
+		return nil, fmt.Errorf("unsupported value %v for type %s", v, typ)
+	}
+
+	info := basic.Info()
+	switch {
+	case info&types.IsBoolean != 0 && val.Kind() == constant.Bool,
+		info&types.IsString != 0 && val.Kind() == constant.String:
+		return val, nil
+	case info&types.IsInteger != 0 && val.Kind() == constant.Int:
+		if !fitsInteger(basic, val) {
+			return nil, fmt.Errorf("value %v overflows %s", v, typ)
+		}
+		return val, nil
+	case info&types.IsFloat != 0 && (val.Kind() == constant.Int || val.Kind() == constant.Float):
+		return constant.ToFloat(val), nil
+	}
+
+	return nil, fmt.Errorf("value %v is not a valid %s", v, typ)
+}
+
+// fitsInteger reports whether an integer constant is in the range of an integer type,
+// taking int and uint as 64 bits wide.
+func fitsInteger(basic *types.Basic, val constant.Value) bool {
+	bits := uint(64)
+	switch basic.Kind() {
+	case types.Int8, types.Uint8:
+		bits = 8
+	case types.Int16, types.Uint16:
+		bits = 16
+	case types.Int32, types.Uint32:
+		bits = 32
+	}
+	one := constant.MakeInt64(1)
+	lo, hi := constant.MakeInt64(0), constant.Shift(one, token.SHL, bits)
+	if basic.Info()&types.IsUnsigned == 0 {
+		hi = constant.Shift(one, token.SHL, bits-1)
+		lo = constant.UnaryOp(token.SUB, hi, 0)
+	}
+
+	return constant.Compare(val, token.GEQ, lo) && constant.Compare(val, token.LSS, hi)
+}
+
+// CheckTemplateFields verifies that field chains such as {{ .User.Name }} exist on the variable types.
+func (tc *TypeChecker) CheckTemplateFields(tpl string, vars map[string]types.Type) error {
+	tree := parse.New("")
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse(tpl, "", "", map[string]*parse.Tree{}); err != nil {
+		return fmt.Errorf("unparseable template: %w", err)
+	}
+
+	pkg := tc.scope(nil)
+	var errs []string
+	walkArgs(tree.Root, func(node parse.Node, call string, arg int) {
+		if str, ok := node.(*parse.StringNode); ok {
+			if want := funcConsts[call]; arg >= 1 && arg <= len(want) && want[arg-1] != nil && !slices.Contains(want[arg-1], str.Text) {
+				errs = append(errs, fmt.Sprintf("%s applied to %s, want %s", call, str.Quoted, strings.Join(want[arg-1], ", ")))
+			}
+			return
+		}
+		idents := node.(*parse.FieldNode).Ident
+		typ, ok := vars[idents[0]]
+		if !ok {
+			if call != "" { // only variables in {{ .Var }} actions are inferred
+				errs = append(errs, fmt.Sprintf("unknown variable used in template function: %s", idents[0]))
+			}
+			return
+		}
+		for i, ident := range idents[1:] {
+			obj, _, _ := types.LookupFieldOrMethod(typ, true, pkg, ident)
+			if obj == nil {
+				errs = append(errs, fmt.Sprintf("unknown field %s in .%s", ident, strings.Join(idents[:i+2], ".")))
+				return
+			}
+			switch obj := obj.(type) {
+			case *types.Var:
+				typ = obj.Type()
+			case *types.Func:
+				sig := obj.Type().(*types.Signature)
+				if sig.Results().Len() == 0 {
+					errs = append(errs, fmt.Sprintf("method %s in .%s returns no value", ident, strings.Join(idents[:i+2], ".")))
+					return
+				}
+				typ = sig.Results().At(0).Type()
+			}
+		}
+		if want := funcArgs[call]; arg >= 1 && arg <= len(want) {
+			if !slices.ContainsFunc(want[arg-1], func(w string) bool { return acceptsType(w, typ) }) {
+				errs = append(errs, fmt.Sprintf("%s applied to .%s of type %s, want %s",
+					call, strings.Join(idents, "."), types.TypeString(typ, (*types.Package).Name), strings.Join(want[arg-1], " or ")))
+			}
+		}
+	})
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid template: %s", strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// funcArgs holds the types template functions formatting values accept, by function name and argument position.
+var funcArgs = map[string][][]string{
+	"number":       {{"number"}},
+	"percent":      {{"number"}},
+	"decimal":      {{"number"}},
+	"currency":     {{"number", "i18nrt.Money"}, {"string", "currency.Unit"}},
+	"date":         {{"time.Time"}},
+	"time":         {{"time.Time"}},
+	"datetime":     {{"time.Time"}},
+	"relativeTime": {{"time.Time", "time.Duration"}},
+}
+
+// timeStyles are the styles of date and time functions.
+var timeStyles = []string{"short", "medium", "long"}
+
+// funcConsts holds the values template functions accept as string constants, by function name and argument position.
+var funcConsts = map[string][][]string{
+	"date":     {nil, timeStyles},
+	"time":     {nil, timeStyles},
+	"datetime": {nil, timeStyles},
+}
+
+// acceptsType reports whether typ is the type named want, or of its kind for "number" and "string".
+func acceptsType(want string, typ types.Type) bool {
+	basic, _ := typ.Underlying().(*types.Basic)
+	switch want {
+	case "number":
+		return basic != nil && basic.Info()&types.IsNumeric != 0
+	case "string":
+		return basic != nil && basic.Info()&types.IsString != 0
+	}
+	return types.TypeString(typ, (*types.Package).Name) == want
+}
+
+// walkArgs calls fn for every field chain evaluated against the template root data,
+// and every string constant passed to a function, with the function and argument position it is passed to, if any.
+// The result of a command in a pipeline is passed as the last argument of the next command.
+// Fields inside range and with blocks are skipped since dot changes there.
+func walkArgs(node parse.Node, fn func(node parse.Node, call string, arg int)) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			walkArgs(c, fn)
+		}
+	case *parse.ActionNode:
+		walkArgs(n.Pipe, fn)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for i, cmd := range n.Cmds {
+			if i+1 < len(n.Cmds) && len(cmd.Args) == 1 {
+				next := n.Cmds[i+1].Args
+				call, ok := next[0].(*parse.IdentifierNode)
+				switch cmd.Args[0].(type) {
+				case *parse.FieldNode, *parse.StringNode:
+					if ok {
+						fn(cmd.Args[0], call.Ident, len(next))
+						continue
+					}
+				}
+			}
+			walkArgs(cmd, fn)
+		}
+	case *parse.CommandNode:
+		if call, ok := n.Args[0].(*parse.IdentifierNode); ok {
+			for i, arg := range n.Args[1:] {
+				switch arg.(type) {
+				case *parse.FieldNode, *parse.StringNode:
+					fn(arg, call.Ident, i+1)
+				default:
+					walkArgs(arg, fn)
+				}
+			}
+			return
+		}
+		for _, arg := range n.Args {
+			walkArgs(arg, fn)
+		}
+	case *parse.FieldNode:
+		fn(n, "", 0)
+	case *parse.IfNode:
+		walkArgs(n.Pipe, fn)
+		walkArgs(n.List, fn)
+		walkArgs(n.ElseList, fn)
+	case *parse.RangeNode:
+		walkArgs(n.Pipe, fn)
+		walkArgs(n.ElseList, fn)
+	case *parse.WithNode:
+		walkArgs(n.Pipe, fn)
+		walkArgs(n.ElseList, fn)
+	}
+}
@@ -0,0 +1,129 @@
+package validator_test
+
+import (
+	"go/constant"
+	"go/types"
+	"math"
+	"testing"
+
+	"github.com/danicc097/i18ngo/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeChecker(t *testing.T) {
+	tc, err := validator.NewTypeChecker([]string{"time"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"time"}, tc.PackageNames())
+
+	vars, err := tc.CheckVariables(map[string]string{"At": "time.Time", "Count": "int", "Any": "interface{}"})
+	require.NoError(t, err)
+	require.Equal(t, "time.Time", vars["At"].String())
+
+	_, err = tc.CheckVariables(map[string]string{"At": "time.Tim"})
+	require.ErrorContains(t, err, `unknown type "time.Tim" for variable At`)
+
+	_, err = validator.NewTypeChecker([]string{"crypto/rand", "math/rand"})
+	require.ErrorContains(t, err, `imports "crypto/rand" and "math/rand" have the same package name rand`)
+
+	exprVars := map[string]types.Type{"at": vars["At"], "count": vars["Count"]}
+
+	tests := []struct {
+		name        string
+		expr        string
+		errContains string
+	}{
+		{name: "comparison", expr: "count == 1"},
+		{name: "method call", expr: "at.Weekday() == time.Sunday && count > 0"},
+		{name: "mismatched types", expr: `count == "1"`, errContains: "mismatched types"},
+		{name: "not boolean", expr: "count + 1", errContains: "expression must be boolean"},
+		{name: "unknown method", expr: "at.Weekdy() == time.Sunday", errContains: "invalid expression"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tc.CheckExpression(tt.expr, exprVars)
+			if tt.errContains != "" {
+				require.ErrorContains(t, err, tt.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	require.NoError(t, tc.CheckTemplateFields("{{ .At.Year }} {{ .Count }} {{ .Unknown.Field }}", vars))
+	require.ErrorContains(t, tc.CheckTemplateFields("{{ .At.Yaer }}", vars), "unknown field Yaer in .At.Yaer")
+	require.ErrorContains(t, tc.CheckTemplateFields("{{ .Count.Foo }}", vars), "unknown field Foo in .Count.Foo")
+	require.NoError(t, tc.CheckTemplateFields(`{{ upper .At.Weekday }} {{ printf "%d" .Count }}`, vars))
+	require.ErrorContains(t, tc.CheckTemplateFields("{{ upper .Unknown }}", vars), "unknown variable used in template function: Unknown")
+	require.NoError(t, tc.CheckTemplateFields(`{{ date .At "long" }} {{ relativeTime .At }}`, vars))
+	require.ErrorContains(t, tc.CheckTemplateFields("{{ date .Count }}", vars), "date applied to .Count of type int, want time.Time")
+	require.ErrorContains(t, tc.CheckTemplateFields("{{ relativeTime .At.Year }}", vars), "relativeTime applied to .At.Year of type int, want time.Time or time.Duration")
+	require.NoError(t, tc.CheckTemplateFields(`{{ currency .Count "EUR" }} {{ number .At.Year }}`, vars))
+	require.ErrorContains(t, tc.CheckTemplateFields(`{{ currency .At .Count }}`, vars),
+		"currency applied to .At of type time.Time, want number or i18nrt.Money, currency applied to .Count of type int, want string or currency.Unit")
+	require.NoError(t, tc.CheckTemplateFields(`{{ .At | date }} {{ "long" | date .At }} {{ .At.Year | number }}`, vars))
+	require.ErrorContains(t, tc.CheckTemplateFields("{{ .Count | date }}", vars), "date applied to .Count of type int, want time.Time")
+	require.ErrorContains(t, tc.CheckTemplateFields(`{{ "EUR" | currency .At }}`, vars), "currency applied to .At of type time.Time, want number or i18nrt.Money")
+	require.NoError(t, tc.CheckTemplateFields(`{{ .At | relativeTime | upper }} {{ .Count | printf "%d" | upper }}`, vars))
+	require.ErrorContains(t, tc.CheckTemplateFields(`{{ datetime .At "full" }}`, vars), `datetime applied to "full", want short, medium, long`)
+	require.ErrorContains(t, tc.CheckTemplateFields(`{{ "full" | date .At }}`, vars), `date applied to "full", want short, medium, long`)
+
+	moneyTC, err := validator.NewTypeChecker([]string{"github.com/danicc097/i18ngo/i18nrt"})
+	require.NoError(t, err)
+	moneyVars, err := moneyTC.CheckVariables(map[string]string{"Total": "i18nrt.Money"})
+	require.NoError(t, err)
+	require.NoError(t, moneyTC.CheckTemplateFields(`{{ currency .Total }} {{ .Total | currency }}`, moneyVars))
+}
+
+func TestEvalExpression(t *testing.T) {
+	tc, err := validator.NewTypeChecker([]string{"time"})
+	require.NoError(t, err)
+	vars, err := tc.CheckVariables(map[string]string{"At": "time.Time", "Count": "int", "Ratio": "float32"})
+	require.NoError(t, err)
+	exprVars := map[string]types.Type{"at": vars["At"], "count": vars["Count"], "ratio": vars["Ratio"]}
+
+	count, err := validator.ExampleValue(vars["Count"], 2)
+	require.NoError(t, err)
+	ratio, err := validator.ExampleValue(vars["Ratio"], 1)
+	require.NoError(t, err)
+	values := map[string]constant.Value{"count": count, "ratio": ratio}
+
+	tests := []struct {
+		name   string
+		expr   string
+		result bool
+		ok     bool
+	}{
+		{name: "true", expr: "count > 1 && ratio == 1", result: true, ok: true},
+		{name: "false", expr: "count == 1", result: false, ok: true},
+		{name: "not constant", expr: "at.Weekday() == time.Sunday", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok, err := tc.EvalExpression(tt.expr, exprVars, values)
+			require.NoError(t, err)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.result, result)
+		})
+	}
+
+	_, err = validator.ExampleValue(vars["Count"], "2")
+	require.ErrorContains(t, err, "value 2 is not a valid int")
+	_, err = validator.ExampleValue(vars["Count"], 2.5)
+	require.ErrorContains(t, err, "value 2.5 is not a valid int")
+	_, err = validator.ExampleValue(vars["At"], "2024-01-01")
+	require.ErrorContains(t, err, "unsupported type time.Time")
+
+	ints, err := tc.CheckVariables(map[string]string{"Small": "int8", "Total": "uint64"})
+	require.NoError(t, err)
+	total, err := validator.ExampleValue(ints["Total"], uint64(math.MaxUint64))
+	require.NoError(t, err)
+	require.Equal(t, "18446744073709551615", total.ExactString())
+	_, err = validator.ExampleValue(vars["Count"], uint64(math.MaxUint64))
+	require.ErrorContains(t, err, "value 18446744073709551615 overflows int")
+	_, err = validator.ExampleValue(ints["Total"], -1)
+	require.ErrorContains(t, err, "value -1 overflows uint64")
+	_, err = validator.ExampleValue(ints["Small"], -128)
+	require.NoError(t, err)
+	_, err = validator.ExampleValue(ints["Small"], 128)
+	require.ErrorContains(t, err, "value 128 overflows int8")
+}
@@ -35,6 +35,62 @@ func TestCompareTranslationFiles(t *testing.T) {
       "count == 0": "b"`,
 			},
 		},
+		{
+			name: "Imports are not compared",
+			files: map[string]string{
+				"data/en.i18ngo.yaml": `imports:
+  - time
+messages:
+  my_greeting:
+    template: "a"`,
+				"data/es.i18ngo.yaml": `messages:
+  my_greeting:
+    template: "b"`,
+			},
+		},
+		{
+			name: "Examples are not compared",
+			files: map[string]string{
+				"data/en.i18ngo.yaml": `messages:
+  my_greeting:
+    template: "a"
+    examples:
+      - want: "a"`,
+				"data/es.i18ngo.yaml": `messages:
+  my_greeting:
+    template: "b"`,
+			},
+		},
+		{
+			name: "Plural and ordinal forms are not compared",
+			files: map[string]string{
+				"data/en.i18ngo.yaml": `messages:
+  my_greeting:
+    plural:
+      variable: Count
+      one: "a"
+      other: "a"
+  my_rank:
+    ordinal:
+      variable: Rank
+      one: "a"
+      two: "a"
+      few: "a"
+      other: "a"`,
+				"data/pl.i18ngo.yaml": `messages:
+  my_greeting:
+    plural:
+      variable: Count
+      one: "b"
+      few: "b"
+      many: "b"
+      other: "b"
+  my_rank:
+    ordinal:
+      variable: Rank
+      other: "b"`,
+			},
+		},
 		{
 			name: "Mismatched structures",
 			files: map[string]string{